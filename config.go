@@ -8,7 +8,6 @@ package log
 
 import (
 	"context"
-	"os"
 	"sync"
 	"sync/atomic"
 )
@@ -16,27 +15,27 @@ import (
 var (
 	defaultLogger    atomicLogger
 	setDefaultLogger sync.Once
-
-	fallback = &LevelFilter{
-		Min:    Info,
-		Output: New(os.Stderr, "", StdFlags, nil),
-	}
 )
 
-// Default returns the global logger. Until SetDefault is called, the returned
-// Logger will send all entries with a level of at least Info to stderr.
+// Default returns the global logger. Until SetDefault or ReplaceDefault is
+// called, the returned Logger will send all entries with a level of at least
+// Info to stderr. This can be changed with the environment variable named by
+// FallbackEnv.
 func Default() Logger {
 	return &defaultLogger
 }
 
 // SetDefault sets the global logger.  It can only be called once.
+// Entries held by BufferUntilConfigured are sent to l.
+// Programs that need to change the global logger later, like when reloading
+// configuration, should use ReplaceDefault instead.
 func SetDefault(l Logger) {
 	ok := false
 	setDefaultLogger.Do(func() {
 		if l == nil {
 			panic("log.SetDefaultLogger(nil)")
 		}
-		defaultLogger.out.Store(l)
+		defaultLogger.replace(l)
 		ok = true
 	})
 	// Panic outside the sync.Once so as not to block future critical regions in
@@ -46,22 +45,113 @@ func SetDefault(l Logger) {
 	}
 }
 
+// ReplaceDefault sets the global logger to l and returns the previous global
+// logger. ReplaceDefault waits for calls to the previous logger's Log method
+// made through Default to return, so the caller may flush or close the previous
+// logger afterward. If the global logger had not been set, ReplaceDefault
+// returns the logger that Default used until then. Entries held by
+// BufferUntilConfigured are sent to l before ReplaceDefault returns.
+//
+// ReplaceDefault may be called any number of times, before or after SetDefault.
+// It must not be called from a Logger's Log method.
+func ReplaceDefault(l Logger) (old Logger) {
+	if l == nil {
+		panic("log.ReplaceDefault(nil)")
+	}
+	return defaultLogger.replace(l)
+}
+
 type atomicLogger struct {
-	out atomic.Value
+	mu  sync.Mutex   // serializes calls to replace
+	out atomic.Value // *loggerRef
+}
+
+// A loggerRef counts the Log calls in progress for an installed logger.
+type loggerRef struct {
+	logger Logger
+	// refs is the number of Log calls in progress, plus one
+	// while the logger is installed.
+	refs    int32
+	drained chan struct{} // closed when refs reaches zero
+}
+
+var fallbackRef = newLoggerRef(fallback)
+
+func newLoggerRef(l Logger) *loggerRef {
+	return &loggerRef{
+		logger:  l,
+		refs:    1,
+		drained: make(chan struct{}),
+	}
+}
+
+// acquire increments the number of calls in progress. It returns false if
+// the logger has been replaced and all its calls have finished.
+func (ref *loggerRef) acquire() bool {
+	for {
+		n := atomic.LoadInt32(&ref.refs)
+		if n == 0 {
+			return false
+		}
+		if atomic.CompareAndSwapInt32(&ref.refs, n, n+1) {
+			return true
+		}
+	}
+}
+
+// release decrements the number of calls in progress. It reports whether
+// the count reached zero, in which case ref.drained is closed.
+func (ref *loggerRef) release() bool {
+	if atomic.AddInt32(&ref.refs, -1) != 0 {
+		return false
+	}
+	close(ref.drained)
+	return true
 }
 
 func (l *atomicLogger) Log(ctx context.Context, ent Entry) {
-	l.logger().Log(ctx, ent)
+	for {
+		ref := l.ref()
+		if ref.acquire() {
+			defer ref.release()
+			ref.logger.Log(ctx, ent)
+			return
+		}
+	}
 }
 
 func (l *atomicLogger) LogEnabled(ent Entry) bool {
-	return l.logger().LogEnabled(ent)
+	return l.ref().logger.LogEnabled(ent)
+}
+
+func (l *atomicLogger) LogEnabledContext(ctx context.Context, ent Entry) bool {
+	return LogEnabledContext(ctx, l.ref().logger, ent)
 }
 
-func (l *atomicLogger) logger() Logger {
+func (l *atomicLogger) ref() *loggerRef {
 	out := l.out.Load()
 	if out == nil {
+		return fallbackRef
+	}
+	return out.(*loggerRef)
+}
+
+// replace installs newLogger and returns the previous logger
+// after its Log calls have finished.
+func (l *atomicLogger) replace(newLogger Logger) Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	old := l.ref()
+	l.out.Store(newLoggerRef(newLogger))
+	old.release()
+	<-old.drained
+	if b, ok := old.logger.(*startupBuffer); ok {
+		// The buffer is replayed after the swap so that a logger that logs
+		// to the global logger while handling a replayed entry does not
+		// deadlock. Only entries logged concurrently with the swap can be
+		// sent to newLogger before the replayed entries.
+		b.replay(newLogger)
 		return fallback
 	}
-	return out.(Logger)
+	return old.logger
 }
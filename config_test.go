@@ -0,0 +1,132 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package log
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReplaceDefault(t *testing.T) {
+	ctx := context.Background()
+	first := new(countLogger)
+	orig := ReplaceDefault(first)
+	defer ReplaceDefault(orig)
+
+	Infof(ctx, "Hello")
+	second := new(countLogger)
+	if old := ReplaceDefault(second); old != first {
+		t.Errorf("ReplaceDefault(...) = %v; want first logger", old)
+	}
+	Infof(ctx, "World")
+	if got := first.count(); got != 1 {
+		t.Errorf("first logger got %d entries; want 1", got)
+	}
+	if got := second.count(); got != 1 {
+		t.Errorf("second logger got %d entries; want 1", got)
+	}
+}
+
+func TestReplaceDefaultDrains(t *testing.T) {
+	ctx := context.Background()
+	blocking := &blockLogger{
+		entered: make(chan struct{}),
+		unblock: make(chan struct{}),
+	}
+	orig := ReplaceDefault(blocking)
+	defer ReplaceDefault(orig)
+
+	logDone := make(chan struct{})
+	go func() {
+		defer close(logDone)
+		Infof(ctx, "Hello")
+	}()
+	<-blocking.entered
+
+	replaced := make(chan Logger)
+	go func() {
+		replaced <- ReplaceDefault(new(countLogger))
+	}()
+	select {
+	case <-replaced:
+		t.Fatal("ReplaceDefault returned while Log was in progress")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	// New calls go to the new logger while the old one is draining.
+	Infof(ctx, "World")
+
+	close(blocking.unblock)
+	if old := <-replaced; old != blocking {
+		t.Errorf("ReplaceDefault(...) = %v; want blocking logger", old)
+	}
+	<-logDone
+}
+
+func TestReplaceDefaultConcurrent(t *testing.T) {
+	ctx := context.Background()
+	orig := ReplaceDefault(new(countLogger))
+	defer ReplaceDefault(orig)
+
+	const (
+		goroutines = 4
+		perRoutine = 200
+	)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perRoutine; j++ {
+				Infof(ctx, "Hello")
+			}
+		}()
+	}
+	total := int64(0)
+	for i := 0; i < 20; i++ {
+		old := ReplaceDefault(new(countLogger))
+		total += old.(*countLogger).count()
+	}
+	wg.Wait()
+	total += ReplaceDefault(new(countLogger)).(*countLogger).count()
+	if total != goroutines*perRoutine {
+		t.Errorf("loggers received %d entries; want %d", total, goroutines*perRoutine)
+	}
+}
+
+type countLogger struct {
+	n int64
+}
+
+func (l *countLogger) Log(ctx context.Context, ent Entry) {
+	atomic.AddInt64(&l.n, 1)
+}
+
+func (l *countLogger) LogEnabled(ent Entry) bool {
+	return true
+}
+
+func (l *countLogger) count() int64 {
+	return atomic.LoadInt64(&l.n)
+}
+
+type blockLogger struct {
+	entered chan struct{}
+	unblock chan struct{}
+}
+
+func (l *blockLogger) Log(ctx context.Context, ent Entry) {
+	close(l.entered)
+	<-l.unblock
+}
+
+func (l *blockLogger) LogEnabled(ent Entry) bool {
+	return true
+}
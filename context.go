@@ -0,0 +1,50 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package log
+
+import "context"
+
+type loggerKey struct{}
+
+// WithLogger returns a Context derived from parent that carries l.
+// Debugf, Infof, Warnf, Errorf, and IsEnabledContext send entries to the
+// Logger in their Context instead of the global logger, so an application can
+// route the logs of a request or test without changing the code that logs.
+func WithLogger(parent context.Context, l Logger) context.Context {
+	if l == nil {
+		panic("log.WithLogger(ctx, nil)")
+	}
+	return context.WithValue(parent, loggerKey{}, l)
+}
+
+// FromContext returns the Logger set by WithLogger in ctx
+// or the global logger if ctx does not carry one.
+func FromContext(ctx context.Context) Logger {
+	if l, ok := ctx.Value(loggerKey{}).(Logger); ok {
+		return l
+	}
+	return Default()
+}
+
+type minLevelKey struct{}
+
+// WithMinLevel returns a Context derived from parent that lowers the minimum
+// level of any LevelFilter to min for entries logged with the Context.
+// It does not raise a filter's minimum. This allows an application to log
+// Debug entries for a single request without changing the level for others.
+func WithMinLevel(parent context.Context, min Level) context.Context {
+	return context.WithValue(parent, minLevelKey{}, min)
+}
+
+// minLevel returns the minimum level set by WithMinLevel in ctx or def,
+// whichever is lower.
+func minLevel(ctx context.Context, def Level) Level {
+	if min, ok := ctx.Value(minLevelKey{}).(Level); ok && min < def {
+		return min
+	}
+	return def
+}
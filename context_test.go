@@ -0,0 +1,64 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package log
+
+import (
+	"context"
+	"io/ioutil"
+	"runtime"
+	"testing"
+)
+
+func TestWithLogger(t *testing.T) {
+	tests := []struct {
+		name string
+		log  func(ctx context.Context)
+		want Level
+	}{
+		{"Debugf", func(ctx context.Context) { Debugf(ctx, "Hello") }, Debug},
+		{"Infof", func(ctx context.Context) { Infof(ctx, "Hello") }, Info},
+		{"Warnf", func(ctx context.Context) { Warnf(ctx, "Hello") }, Warn},
+		{"Errorf", func(ctx context.Context) { Errorf(ctx, "Hello") }, Error},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cl := new(captureLogger)
+			ctx := WithLogger(context.Background(), cl)
+			test.log(ctx)
+			if !cl.called {
+				t.Fatal("Logger in Context not called")
+			}
+			if cl.e.Level != test.want || cl.e.Msg != "Hello" {
+				t.Errorf("entry = %v; want %v entry with message \"Hello\"", cl.e, test.want)
+			}
+			if _, file, _, _ := runtime.Caller(0); cl.e.File != file {
+				t.Errorf("e.File = %q; want %q", cl.e.File, file)
+			}
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	if got := FromContext(context.Background()); got != Default() {
+		t.Errorf("FromContext(context.Background()) = %v; want Default()", got)
+	}
+	cl := new(captureLogger)
+	outer := WithLogger(context.Background(), new(captureLogger))
+	if got := FromContext(WithLogger(outer, cl)); got != cl {
+		t.Errorf("FromContext(...) = %v; want innermost logger", got)
+	}
+}
+
+func TestIsEnabledContext(t *testing.T) {
+	ctx := WithLogger(context.Background(), &LevelFilter{Min: Warn, Output: New(ioutil.Discard, "", 0, nil)})
+	if IsEnabledContext(ctx, Info) {
+		t.Error("IsEnabledContext(ctx, Info) = true; want false")
+	}
+	if !IsEnabledContext(ctx, Error) {
+		t.Error("IsEnabledContext(ctx, Error) = false; want true")
+	}
+}
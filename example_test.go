@@ -29,15 +29,98 @@ func Example() {
 	// Hello, World!
 }
 
-// TODO(someday): ExampleLevelFilter should be an output test, but SetDefault
-// can only be called once.
-
 func ExampleLevelFilter() {
-	log.SetDefault(&log.LevelFilter{
+	log.ReplaceDefault(&log.LevelFilter{
 		Min:    log.Warn, // Only show warnings or above
 		Output: log.New(os.Stdout, "", 0, nil),
 	})
 	ctx := context.Background()
 	log.Infof(ctx, "This won't show up.")
 	log.Warnf(ctx, "Only Warn or higher will show up.")
+
+	// Output:
+	// Only Warn or higher will show up.
+}
+
+func ExampleReplaceDefault() {
+	ctx := context.Background()
+	f, err := os.Create("app.log")
+	if err != nil {
+		log.Errorf(ctx, "%v", err)
+		return
+	}
+	log.ReplaceDefault(log.New(f, "", log.StdFlags, nil))
+
+	// Later, to reopen the log file after it has been rotated:
+	newFile, err := os.Create("app.log")
+	if err != nil {
+		log.Errorf(ctx, "%v", err)
+		return
+	}
+	log.ReplaceDefault(log.New(newFile, "", log.StdFlags, nil))
+	// ReplaceDefault waits for in-progress calls to the old logger to finish,
+	// so the old file can be safely closed.
+	f.Close()
+}
+
+func ExampleWithLogger() {
+	// Send entries for a particular request or tenant to a different Logger.
+	tenantLog := log.New(os.Stdout, "[tenant-a] ", 0, nil)
+	ctx := log.WithLogger(context.Background(), tenantLog)
+
+	// Library code logs as usual.
+	log.Infof(ctx, "Hello, World!")
+
+	// Output:
+	// [tenant-a] Hello, World!
+}
+
+func ExampleRouter() {
+	type tenantKey struct{}
+	router := log.NewRouter(
+		func(ctx context.Context) string {
+			tenant, _ := ctx.Value(tenantKey{}).(string)
+			return tenant
+		},
+		func(tenant string) (log.Logger, error) {
+			// A real program might open a file for each tenant here.
+			// Loggers that implement io.Closer are closed when evicted.
+			return log.New(os.Stdout, "["+tenant+"] ", 0, nil), nil
+		},
+		&log.RouterOptions{
+			Default:   log.New(os.Stdout, "[default] ", 0, nil),
+			MaxRoutes: 100,
+		},
+	)
+	defer router.Close()
+
+	ctx := context.Background()
+	log.Logf(context.WithValue(ctx, tenantKey{}, "tenant-a"), router, log.Info, "Job started")
+	log.Logf(ctx, router, log.Info, "Idle")
+
+	// Output:
+	// [tenant-a] Job started
+	// [default] Idle
+}
+
+func ExampleLevelRouter() {
+	router := log.NewLevelRouter(
+		// Error entries go to both routes with Min: log.Error.
+		log.LevelRoute{Min: log.Error, Output: log.New(os.Stdout, "stderr: ", 0, nil)},
+		log.LevelRoute{Min: log.Error, Output: log.New(os.Stdout, "pager: ", 0, nil)},
+		// Info and Warn entries go to stdout.
+		log.LevelRoute{Min: log.Info, Output: log.New(os.Stdout, "stdout: ", 0, nil)},
+		// A real program might write Debug entries to a file.
+		log.LevelRoute{Min: log.Debug, Output: log.Discard},
+	)
+
+	ctx := context.Background()
+	log.Logf(ctx, router, log.Debug, "Connecting")
+	log.Logf(ctx, router, log.Warn, "Retrying")
+	log.Logf(ctx, router, log.Error, "Connection failed")
+
+	// Output:
+	// stdout: Retrying
+	// stderr: Connection failed
+	// pager: Connection failed
 }
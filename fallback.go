@@ -0,0 +1,201 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FallbackEnv is the name of the environment variable that configures
+// the logger used until SetDefault is called. It is read once, the first time
+// an entry is sent to the fallback logger. Its value is a comma-separated list
+// of case-insensitive options:
+//
+//	debug, info, warn, error     minimum level (default info)
+//	text, json                   output format (default text)
+//	stderr, stdout, file=PATH    destination (default stderr)
+//	flags=ShowDate|ShowTime|...  Flags for the text format (default StdFlags);
+//	                             an empty list means no flags
+//
+// For example, ZLOG_FALLBACK=debug,json,stdout writes entries of every
+// predefined level to stdout as JSON objects with the keys "time", "level",
+// "msg", "file", and "line".
+// Unknown options are reported with a warning.
+const FallbackEnv = "ZLOG_FALLBACK"
+
+var fallback = new(lazyFallback)
+
+// lazyFallback is the logger used until the global logger is set.
+type lazyFallback struct {
+	once sync.Once
+	l    Logger
+}
+
+func (f *lazyFallback) logger() Logger {
+	f.once.Do(func() {
+		var warnings []string
+		f.l, warnings = newFallback(os.Getenv(FallbackEnv))
+		for _, w := range warnings {
+			f.l.Log(context.Background(), Entry{
+				Msg:   "log: " + w,
+				Time:  time.Now(),
+				Level: Warn,
+			})
+		}
+	})
+	return f.l
+}
+
+func (f *lazyFallback) Log(ctx context.Context, ent Entry) {
+	f.logger().Log(ctx, ent)
+}
+
+func (f *lazyFallback) LogEnabled(ent Entry) bool {
+	return f.logger().LogEnabled(ent)
+}
+
+func (f *lazyFallback) LogEnabledContext(ctx context.Context, ent Entry) bool {
+	return LogEnabledContext(ctx, f.logger(), ent)
+}
+
+// newFallback returns the fallback logger described by the value of
+// FallbackEnv along with any problems with the value.
+func newFallback(config string) (Logger, []string) {
+	min := Info
+	var out io.Writer = os.Stderr
+	jsonFormat := false
+	flags := StdFlags
+	var warnings []string
+	for _, opt := range strings.Split(config, ",") {
+		opt = strings.TrimSpace(opt)
+		switch lower := strings.ToLower(opt); {
+		case opt == "":
+		case lower == "debug":
+			min = Debug
+		case lower == "info":
+			min = Info
+		case lower == "warn" || lower == "warning":
+			min = Warn
+		case lower == "error":
+			min = Error
+		case lower == "text":
+			jsonFormat = false
+		case lower == "json":
+			jsonFormat = true
+		case lower == "stderr":
+			out = os.Stderr
+		case lower == "stdout":
+			out = os.Stdout
+		case strings.HasPrefix(lower, "file="):
+			f, err := os.OpenFile(opt[len("file="):], os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o666)
+			if err != nil {
+				warnings = append(warnings, FallbackEnv+": "+err.Error())
+				continue
+			}
+			out = f
+		case strings.HasPrefix(lower, "flags="):
+			f, ok := parseFlags(opt[len("flags="):])
+			if !ok {
+				warnings = append(warnings, FallbackEnv+": invalid flags "+quote(opt))
+				continue
+			}
+			flags = f
+		default:
+			warnings = append(warnings, FallbackEnv+": unknown option "+quote(opt))
+		}
+	}
+	var output Logger
+	if jsonFormat {
+		output = &jsonWriter{out: out}
+	} else {
+		output = New(out, "", flags, nil)
+	}
+	return &LevelFilter{Min: min, Output: output}, warnings
+}
+
+// parseFlags parses the output of Flags.String, ignoring case.
+func parseFlags(s string) (Flags, bool) {
+	if s == "" {
+		return 0, true
+	}
+	var f Flags
+	for _, name := range strings.Split(strings.ToLower(s), "|") {
+		switch name {
+		case "0":
+		case "showdate":
+			f |= ShowDate
+		case "showtime":
+			f |= ShowTime
+		case "microseconds":
+			f |= Microseconds
+		case "showfile":
+			f |= ShowFile
+		case "shortfile":
+			f |= ShortFile
+		case "utc":
+			f |= UTC
+		case "showlevel":
+			f |= ShowLevel
+		case "stdflags":
+			f |= StdFlags
+		default:
+			return 0, false
+		}
+	}
+	return f, true
+}
+
+func quote(s string) string {
+	return strconv.Quote(s)
+}
+
+// jsonWriter writes each entry to an io.Writer as a line of JSON.
+type jsonWriter struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// jsonEntry is the JSON representation of an Entry written by jsonWriter.
+type jsonEntry struct {
+	Time  string `json:"time,omitempty"`
+	Level string `json:"level"`
+	Msg   string `json:"msg"`
+	File  string `json:"file,omitempty"`
+	Line  int    `json:"line,omitempty"`
+}
+
+func (w *jsonWriter) Log(ctx context.Context, ent Entry) {
+	je := jsonEntry{
+		Level: entryLevel(ent.Level),
+		Msg:   strings.TrimSuffix(ent.Msg, "\n"),
+		File:  ent.File,
+		Line:  ent.Line,
+	}
+	if je.Level == "???" {
+		je.Level = ent.Level.String()
+	}
+	if !ent.Time.IsZero() {
+		je.Time = ent.Time.Format(time.RFC3339Nano)
+	}
+	buf, err := json.Marshal(je)
+	if err != nil {
+		return
+	}
+	buf = append(buf, '\n')
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.out.Write(buf)
+}
+
+func (w *jsonWriter) LogEnabled(Entry) bool { return true }
@@ -0,0 +1,126 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package log
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewFallback(t *testing.T) {
+	tests := []struct {
+		config       string
+		wantMin      Level
+		wantOut      *os.File
+		wantJSON     bool
+		wantFlags    Flags
+		wantWarnings int
+	}{
+		{
+			config:    "",
+			wantMin:   Info,
+			wantOut:   os.Stderr,
+			wantFlags: StdFlags,
+		},
+		{
+			config:   "debug,json,stdout",
+			wantMin:  Debug,
+			wantOut:  os.Stdout,
+			wantJSON: true,
+		},
+		{
+			config:    " WARN , text, flags=ShowLevel|UTC",
+			wantMin:   Warn,
+			wantOut:   os.Stderr,
+			wantFlags: ShowLevel | UTC,
+		},
+		{
+			config:    "flags=showdate|ShowLevel",
+			wantMin:   Info,
+			wantOut:   os.Stderr,
+			wantFlags: ShowDate | ShowLevel,
+		},
+		{
+			config:    "flags=",
+			wantMin:   Info,
+			wantOut:   os.Stderr,
+			wantFlags: 0,
+		},
+		{
+			config:       "error,bogus,flags=Nope",
+			wantMin:      Error,
+			wantOut:      os.Stderr,
+			wantFlags:    StdFlags,
+			wantWarnings: 2,
+		},
+	}
+	for _, test := range tests {
+		l, warnings := newFallback(test.config)
+		if len(warnings) != test.wantWarnings {
+			t.Errorf("newFallback(%q) warnings = %q; want %d warnings", test.config, warnings, test.wantWarnings)
+		}
+		filter := l.(*LevelFilter)
+		if filter.Min != test.wantMin {
+			t.Errorf("newFallback(%q) min level = %v; want %v", test.config, filter.Min, test.wantMin)
+		}
+		switch out := filter.Output.(type) {
+		case *jsonWriter:
+			if !test.wantJSON {
+				t.Errorf("newFallback(%q) uses JSON; want text", test.config)
+			}
+			if out.out != test.wantOut {
+				t.Errorf("newFallback(%q) writes to %v; want %v", test.config, out.out, test.wantOut)
+			}
+		case *Writer:
+			if test.wantJSON {
+				t.Errorf("newFallback(%q) uses text; want JSON", test.config)
+			}
+			if out.out != test.wantOut {
+				t.Errorf("newFallback(%q) writes to %v; want %v", test.config, out.out, test.wantOut)
+			}
+			if out.flag != test.wantFlags {
+				t.Errorf("newFallback(%q) flags = %v; want %v", test.config, out.flag, test.wantFlags)
+			}
+		default:
+			t.Errorf("newFallback(%q) output = %T", test.config, out)
+		}
+	}
+}
+
+func TestNewFallbackFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "log_fallback")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "fallback.log")
+	l, warnings := newFallback("json,file=" + path)
+	if len(warnings) > 0 {
+		t.Fatal("newFallback warnings:", warnings)
+	}
+	l.Log(context.Background(), Entry{Msg: "Hello", Level: Warn})
+	l.(*LevelFilter).Output.(*jsonWriter).out.(*os.File).Close()
+	got, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"level":"WARN","msg":"Hello"}` + "\n"; string(got) != want {
+		t.Errorf("file content = %q; want %q", got, want)
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	for _, f := range []Flags{0, StdFlags, ShowDate | Microseconds | ShortFile, allFlags} {
+		got, ok := parseFlags(f.String())
+		if !ok || got != f {
+			t.Errorf("parseFlags(%q) = %v, %t; want %v, true", f.String(), got, ok, f)
+		}
+	}
+}
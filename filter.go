@@ -16,9 +16,9 @@ type LevelFilter struct {
 }
 
 // Log sends the entry to the filter's output if the entry's level is at least
-// the filter's minimum.
+// the filter's minimum or the minimum set by WithMinLevel in ctx.
 func (f *LevelFilter) Log(ctx context.Context, e Entry) {
-	if e.Level < f.Min {
+	if e.Level < minLevel(ctx, f.Min) {
 		return
 	}
 	f.Output.Log(ctx, e)
@@ -32,3 +32,13 @@ func (f *LevelFilter) LogEnabled(e Entry) bool {
 	}
 	return f.Output.LogEnabled(e)
 }
+
+// LogEnabledContext returns false if the entry's level is below both the
+// filter's minimum and the minimum set by WithMinLevel in ctx, otherwise it
+// returns the result of LogEnabledContext(ctx, f.Output, e).
+func (f *LevelFilter) LogEnabledContext(ctx context.Context, e Entry) bool {
+	if e.Level < minLevel(ctx, f.Min) {
+		return false
+	}
+	return LogEnabledContext(ctx, f.Output, e)
+}
@@ -8,6 +8,7 @@ package log
 
 import (
 	"context"
+	"io/ioutil"
 	"testing"
 	"time"
 
@@ -98,3 +99,75 @@ func TestLevelFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestLevelFilterContext(t *testing.T) {
+	type debugKey struct{}
+	f := &LevelFilter{
+		Min:    Info,
+		Output: &contextEnabler{key: debugKey{}},
+	}
+	ctx := context.Background()
+	debugCtx := context.WithValue(ctx, debugKey{}, true)
+	tests := []struct {
+		name  string
+		ctx   context.Context
+		level Level
+		want  bool
+	}{
+		{"BelowMin", debugCtx, Debug, false},
+		{"OutputDisabled", ctx, Info, false},
+		{"OutputEnabled", debugCtx, Info, true},
+	}
+	for _, test := range tests {
+		if got := f.LogEnabledContext(test.ctx, Entry{Level: test.level}); got != test.want {
+			t.Errorf("%s: LogEnabledContext(...) = %t; want %t", test.name, got, test.want)
+		}
+	}
+}
+
+// contextEnabler is a Logger that is enabled only for Contexts
+// that have a value for key.
+type contextEnabler struct {
+	key     interface{}
+	entries []Entry
+}
+
+func (ce *contextEnabler) Log(_ context.Context, ent Entry) {
+	ce.entries = append(ce.entries, ent)
+}
+
+func (*contextEnabler) LogEnabled(Entry) bool { return false }
+
+func (ce *contextEnabler) LogEnabledContext(ctx context.Context, _ Entry) bool {
+	return ctx.Value(ce.key) != nil
+}
+
+func TestLevelFilterWithMinLevel(t *testing.T) {
+	sink := New(ioutil.Discard, "", 0, nil)
+	tests := []struct {
+		name      string
+		filterMin Level
+		ctxMin    Level
+		level     Level
+		want      bool
+	}{
+		{"Lowered", Info, Debug, Debug, true},
+		{"LoweredBelow", Warn, Info, Debug, false},
+		{"NotRaised", Info, Error, Info, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cl := new(captureLogger)
+			f := &LevelFilter{Min: test.filterMin, Output: cl}
+			ctx := WithMinLevel(context.Background(), test.ctxMin)
+			ent := Entry{Level: test.level, Msg: "Hello"}
+			if got := LogEnabledContext(ctx, &LevelFilter{Min: test.filterMin, Output: sink}, ent); got != test.want {
+				t.Errorf("LogEnabledContext(...) = %t; want %t", got, test.want)
+			}
+			f.Log(ctx, ent)
+			if cl.called != test.want {
+				t.Errorf("Log(...) called Output = %t; want %t", cl.called, test.want)
+			}
+		})
+	}
+}
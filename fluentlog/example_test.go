@@ -0,0 +1,27 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package fluentlog_test
+
+import (
+	"context"
+
+	"zombiezen.com/go/log"
+	"zombiezen.com/go/log/fluentlog"
+)
+
+func ExampleNew() {
+	logger := fluentlog.New("localhost:24224", &fluentlog.Options{
+		Tag:        "myapp",
+		RequireAck: true,
+	})
+	log.SetDefault(logger)
+
+	// Send any buffered entries before the program exits.
+	defer logger.Close()
+
+	log.Infof(context.Background(), "Hello, World!")
+}
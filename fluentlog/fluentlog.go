@@ -0,0 +1,271 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package fluentlog provides a Logger that sends entries to Fluentd or
+// Fluent Bit using the Fluent forward protocol.
+//
+// Entries are sent in PackedForward mode. Each entry's record is a map with
+// the keys "message" and "level" and, if the entry has a file name,
+// "file" and "line".
+package fluentlog
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"zombiezen.com/go/log"
+	"zombiezen.com/go/log/internal/backoff"
+	"zombiezen.com/go/log/internal/batch"
+)
+
+// DefaultTag is the tag used if Options.Tag is empty.
+const DefaultTag = "app"
+
+// Options is the set of optional arguments to New.
+type Options struct {
+	// Network is the network passed to net.Dial, like "tcp" or "unix".
+	// If empty, "tcp" is used.
+	Network string
+	// Tag is the Fluent tag attached to all entries.
+	// If empty, DefaultTag is used.
+	Tag string
+	// RequireAck enables at-least-once delivery: each batch carries a chunk ID
+	// and is resent until the server acknowledges it.
+	RequireAck bool
+
+	// DialTimeout is the timeout for establishing a connection.
+	// If zero, 10 seconds is used.
+	DialTimeout time.Duration
+	// WriteTimeout is the timeout for writing a batch and, if RequireAck is
+	// set, reading its acknowledgement. If zero, 10 seconds is used.
+	WriteTimeout time.Duration
+
+	// BatchSize is the maximum number of entries sent in a single message.
+	// If zero, 512 is used.
+	BatchSize int
+	// FlushInterval is the maximum amount of time an entry is buffered before
+	// it is sent. If zero, one second is used.
+	FlushInterval time.Duration
+	// MaxBuffer is the maximum number of entries buffered in memory.
+	// When the buffer is full, the oldest entries are dropped.
+	// If zero, 2048 is used.
+	MaxBuffer int
+	// MaxAttempts is the number of times a message is sent before its entries
+	// are dropped. If zero, a message is retried until Close is called.
+	MaxAttempts int
+	// Backoff is the initial delay between reconnection attempts. Delays double
+	// for each attempt, up to MaxBackoff. If zero, 100 milliseconds is used.
+	Backoff time.Duration
+	// MaxBackoff is the maximum delay between reconnection attempts.
+	// If zero, 30 seconds is used.
+	MaxBackoff time.Duration
+
+	// ErrorFunc is called if not nil when entries are dropped.
+	// It must be safe to call from multiple goroutines and should be fast.
+	ErrorFunc func(context.Context, error)
+}
+
+// Logger is a log.Logger that sends entries in batches to a Fluent forward
+// input from a background goroutine. The connection is established lazily and
+// reestablished after any error. Call Close to stop the goroutine and send any
+// buffered entries.
+type Logger struct {
+	network      string
+	addr         string
+	tag          string
+	requireAck   bool
+	dialTimeout  time.Duration
+	writeTimeout time.Duration
+	q            *batch.Queue
+
+	// conn and r are only accessed by the queue's goroutine and,
+	// after the queue is closed, by Close.
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// New returns a new Logger that sends entries to the given address,
+// like "localhost:24224". opts may be nil, in which case it is
+// treated the same as if new(Options) were passed.
+func New(addr string, opts *Options) *Logger {
+	if opts == nil {
+		opts = new(Options)
+	}
+	l := &Logger{
+		network:      opts.Network,
+		addr:         addr,
+		tag:          opts.Tag,
+		requireAck:   opts.RequireAck,
+		dialTimeout:  opts.DialTimeout,
+		writeTimeout: opts.WriteTimeout,
+	}
+	if l.network == "" {
+		l.network = "tcp"
+	}
+	if l.tag == "" {
+		l.tag = DefaultTag
+	}
+	if l.dialTimeout <= 0 {
+		l.dialTimeout = 10 * time.Second
+	}
+	if l.writeTimeout <= 0 {
+		l.writeTimeout = 10 * time.Second
+	}
+	l.q = batch.New(batch.Options{
+		Send:        l.send,
+		BatchSize:   opts.BatchSize,
+		Interval:    opts.FlushInterval,
+		MaxBuffer:   opts.MaxBuffer,
+		MaxAttempts: opts.MaxAttempts,
+		Backoff: backoff.Policy{
+			Initial: opts.Backoff,
+			Max:     opts.MaxBackoff,
+		},
+		ErrorFunc: opts.ErrorFunc,
+	})
+	return l
+}
+
+// Log buffers the entry to be sent. It does not wait for the entry to be
+// delivered. If the entry has no timestamp, the current time is used.
+func (l *Logger) Log(ctx context.Context, ent log.Entry) {
+	if ent.Time.IsZero() {
+		ent.Time = time.Now()
+	}
+	b := appendEntry(nil, ent)
+	l.q.Add(ctx, b, len(b))
+}
+
+// LogEnabled always returns true.
+func (l *Logger) LogEnabled(log.Entry) bool { return true }
+
+// Flush sends any buffered entries and waits for them to be delivered or
+// dropped. Flush returns an error if any entries were dropped.
+func (l *Logger) Flush(ctx context.Context) error {
+	return l.q.Flush(ctx)
+}
+
+// Close sends any buffered entries, stops the Logger's background goroutine,
+// and closes the connection. Entries logged after Close are dropped.
+func (l *Logger) Close() error {
+	err := l.q.Close()
+	l.disconnect()
+	return err
+}
+
+// appendEntry appends the MessagePack encoding of [time, record].
+func appendEntry(b []byte, ent log.Entry) []byte {
+	b = appendArrayHeader(b, 2)
+	b = appendEventTime(b, ent.Time)
+	n := 2
+	if ent.File != "" {
+		n += 2
+	}
+	b = appendMapHeader(b, n)
+	b = appendString(b, "message")
+	b = appendString(b, strings.TrimSuffix(ent.Msg, "\n"))
+	b = appendString(b, "level")
+	b = appendString(b, levelName(ent.Level))
+	if ent.File != "" {
+		b = appendString(b, "file")
+		b = appendString(b, ent.File)
+		b = appendString(b, "line")
+		b = appendInt(b, int64(ent.Line))
+	}
+	return b
+}
+
+func levelName(level log.Level) string {
+	switch {
+	case level < log.Info:
+		return "debug"
+	case level < log.Warn:
+		return "info"
+	case level < log.Error:
+		return "warn"
+	default:
+		return "error"
+	}
+}
+
+func (l *Logger) send(ctx context.Context, items []interface{}) error {
+	var entries []byte
+	for _, item := range items {
+		entries = append(entries, item.([]byte)...)
+	}
+	var chunk string
+	msg := appendArrayHeader(nil, 3)
+	msg = appendString(msg, l.tag)
+	msg = appendBinary(msg, entries)
+	if l.requireAck {
+		var err error
+		chunk, err = newChunkID()
+		if err != nil {
+			return fmt.Errorf("send to fluent: %w", err)
+		}
+		msg = appendMapHeader(msg, 2)
+		msg = appendString(msg, "chunk")
+		msg = appendString(msg, chunk)
+	} else {
+		msg = appendMapHeader(msg, 1)
+	}
+	msg = appendString(msg, "size")
+	msg = appendInt(msg, int64(len(items)))
+
+	if l.conn == nil {
+		d := &net.Dialer{Timeout: l.dialTimeout}
+		conn, err := d.DialContext(ctx, l.network, l.addr)
+		if err != nil {
+			return fmt.Errorf("send to fluent: %w", err)
+		}
+		l.conn = conn
+		l.r = bufio.NewReader(conn)
+	}
+	if err := l.conn.SetDeadline(time.Now().Add(l.writeTimeout)); err != nil {
+		l.disconnect()
+		return fmt.Errorf("send to fluent: %w", err)
+	}
+	if _, err := l.conn.Write(msg); err != nil {
+		l.disconnect()
+		return fmt.Errorf("send to fluent: %w", err)
+	}
+	if !l.requireAck {
+		return nil
+	}
+	resp, err := readValue(l.r)
+	if err != nil {
+		l.disconnect()
+		return fmt.Errorf("send to fluent: read ack: %w", err)
+	}
+	m, _ := resp.(map[string]interface{})
+	if ack, _ := m["ack"].(string); ack != chunk {
+		l.disconnect()
+		return fmt.Errorf("send to fluent: got ack %q; want %q", ack, chunk)
+	}
+	return nil
+}
+
+func (l *Logger) disconnect() {
+	if l.conn != nil {
+		l.conn.Close()
+		l.conn = nil
+		l.r = nil
+	}
+}
+
+func newChunkID() (string, error) {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(id[:]), nil
+}
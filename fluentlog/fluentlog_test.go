@@ -0,0 +1,310 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package fluentlog
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"zombiezen.com/go/log"
+)
+
+var _ log.Logger = new(Logger)
+
+func TestLogger(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("PackedForward", func(t *testing.T) {
+		srv := newFakeForward(t, 0)
+		defer srv.Close()
+		l := New(srv.Addr(), &Options{
+			Tag:           "myapp.log",
+			FlushInterval: time.Hour,
+		})
+		l.Log(ctx, log.Entry{
+			Msg:   "Hello, World!\n",
+			Time:  time.Unix(1581452772, 123456789),
+			Level: log.Warn,
+			File:  "/src/foo.go",
+			Line:  42,
+		})
+		l.Log(ctx, log.Entry{
+			Msg:   "second",
+			Time:  time.Unix(1581452773, 0),
+			Level: log.Info,
+		})
+		if err := l.Close(); err != nil {
+			t.Error("Close:", err)
+		}
+
+		msgs := srv.wait(t, 1)
+		want := []forwardMessage{{
+			tag: "myapp.log",
+			entries: []forwardEntry{
+				{
+					time: time.Unix(1581452772, 123456789),
+					record: map[string]interface{}{
+						"message": "Hello, World!",
+						"level":   "warn",
+						"file":    "/src/foo.go",
+						"line":    int64(42),
+					},
+				},
+				{
+					time: time.Unix(1581452773, 0),
+					record: map[string]interface{}{
+						"message": "second",
+						"level":   "info",
+					},
+				},
+			},
+			size: 2,
+		}}
+		if diff := cmp.Diff(want, msgs, cmp.AllowUnexported(forwardMessage{}, forwardEntry{})); diff != "" {
+			t.Errorf("messages (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Ack", func(t *testing.T) {
+		srv := newFakeForward(t, 0)
+		defer srv.Close()
+		l := New(srv.Addr(), &Options{
+			RequireAck:    true,
+			FlushInterval: time.Hour,
+		})
+		l.Log(ctx, log.Entry{Msg: "Hello, World!"})
+		if err := l.Flush(ctx); err != nil {
+			t.Error("Flush:", err)
+		}
+		l.Close()
+		msgs := srv.wait(t, 1)
+		if msgs[0].chunk == "" {
+			t.Error("message does not have a chunk option")
+		}
+	})
+
+	t.Run("Reconnect", func(t *testing.T) {
+		// Server hangs up without acknowledging the first two messages.
+		srv := newFakeForward(t, 2)
+		defer srv.Close()
+		l := New(srv.Addr(), &Options{
+			RequireAck:    true,
+			FlushInterval: time.Hour,
+			Backoff:       time.Millisecond,
+		})
+		l.Log(ctx, log.Entry{Msg: "Hello, World!"})
+		if err := l.Flush(ctx); err != nil {
+			t.Error("Flush:", err)
+		}
+		l.Close()
+		msgs := srv.wait(t, 3)
+		if got := srv.connections(); got != 3 {
+			t.Errorf("server received %d connections; want 3", got)
+		}
+		for i, msg := range msgs {
+			if len(msg.entries) != 1 || msg.entries[0].record["message"] != "Hello, World!" {
+				t.Errorf("message[%d] entries = %v; want 1 entry", i, msg.entries)
+			}
+		}
+	})
+}
+
+type forwardMessage struct {
+	tag     string
+	entries []forwardEntry
+	size    int64
+	chunk   string
+}
+
+type forwardEntry struct {
+	time   time.Time
+	record map[string]interface{}
+}
+
+type fakeForward struct {
+	ln       net.Listener
+	errs     chan error
+	received chan forwardMessage
+
+	mu    sync.Mutex
+	hangs int
+	conns int
+	wg    sync.WaitGroup
+}
+
+// newFakeForward starts a forward protocol server on a local port that
+// closes connections without acknowledging the first hangs messages.
+func newFakeForward(t *testing.T, hangs int) *fakeForward {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeForward{
+		ln:       ln,
+		errs:     make(chan error, 10),
+		received: make(chan forwardMessage, 10),
+		hangs:    hangs,
+	}
+	f.wg.Add(1)
+	go f.serve()
+	return f
+}
+
+func (f *fakeForward) Addr() string {
+	return f.ln.Addr().String()
+}
+
+func (f *fakeForward) Close() {
+	f.ln.Close()
+	f.wg.Wait()
+}
+
+func (f *fakeForward) connections() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.conns
+}
+
+func (f *fakeForward) serve() {
+	defer f.wg.Done()
+	for {
+		conn, err := f.ln.Accept()
+		if err != nil {
+			return
+		}
+		f.mu.Lock()
+		f.conns++
+		f.mu.Unlock()
+		f.wg.Add(1)
+		go func() {
+			defer f.wg.Done()
+			defer conn.Close()
+			f.handle(conn)
+		}()
+	}
+}
+
+func (f *fakeForward) handle(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	for {
+		v, err := readValue(r)
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			f.errs <- err
+			return
+		}
+		msg, err := parseForwardMessage(v)
+		if err != nil {
+			f.errs <- err
+			return
+		}
+		f.received <- msg
+
+		f.mu.Lock()
+		hangUp := f.hangs > 0
+		if hangUp {
+			f.hangs--
+		}
+		f.mu.Unlock()
+		if hangUp {
+			return
+		}
+		if msg.chunk != "" {
+			resp := appendMapHeader(nil, 1)
+			resp = appendString(resp, "ack")
+			resp = appendString(resp, msg.chunk)
+			if _, err := conn.Write(resp); err != nil {
+				f.errs <- err
+				return
+			}
+		}
+	}
+}
+
+func (f *fakeForward) wait(t *testing.T, n int) []forwardMessage {
+	t.Helper()
+	var msgs []forwardMessage
+	timeout := time.After(10 * time.Second)
+	for len(msgs) < n {
+		select {
+		case msg := <-f.received:
+			msgs = append(msgs, msg)
+		case err := <-f.errs:
+			t.Fatal("server:", err)
+		case <-timeout:
+			t.Fatalf("received %d messages; want %d", len(msgs), n)
+		}
+	}
+	return msgs
+}
+
+func parseForwardMessage(v interface{}) (forwardMessage, error) {
+	a, ok := v.([]interface{})
+	if !ok || len(a) != 3 {
+		return forwardMessage{}, errorf("message is %#v; want 3-element array", v)
+	}
+	var msg forwardMessage
+	msg.tag, ok = a[0].(string)
+	if !ok {
+		return forwardMessage{}, errorf("tag is %#v; want string", a[0])
+	}
+	data, ok := a[1].([]byte)
+	if !ok {
+		return forwardMessage{}, errorf("entries is %#v; want binary", a[1])
+	}
+	opts, ok := a[2].(map[string]interface{})
+	if !ok {
+		return forwardMessage{}, errorf("option is %#v; want map", a[2])
+	}
+	msg.size, _ = opts["size"].(int64)
+	msg.chunk, _ = opts["chunk"].(string)
+
+	r := bufio.NewReader(bytes.NewReader(data))
+	for {
+		v, err := readValue(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return forwardMessage{}, err
+		}
+		pair, ok := v.([]interface{})
+		if !ok || len(pair) != 2 {
+			return forwardMessage{}, errorf("entry is %#v; want 2-element array", v)
+		}
+		ext, ok := pair[0].(extension)
+		if !ok || ext.Type != eventTimeExt || len(ext.Data) != 8 {
+			return forwardMessage{}, errorf("entry time is %#v; want EventTime", pair[0])
+		}
+		sec := binary.BigEndian.Uint32(ext.Data[:4])
+		nsec := binary.BigEndian.Uint32(ext.Data[4:])
+		record, ok := pair[1].(map[string]interface{})
+		if !ok {
+			return forwardMessage{}, errorf("entry record is %#v; want map", pair[1])
+		}
+		msg.entries = append(msg.entries, forwardEntry{
+			time:   time.Unix(int64(sec), int64(nsec)),
+			record: record,
+		})
+	}
+	return msg, nil
+}
+
+func errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("forward message: "+format, args...)
+}
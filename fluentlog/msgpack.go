@@ -0,0 +1,273 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package fluentlog
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// This file implements the subset of MessagePack
+// (https://github.com/msgpack/msgpack/blob/master/spec.md)
+// needed for the Fluent forward protocol.
+
+func appendArrayHeader(b []byte, n int) []byte {
+	switch {
+	case n < 16:
+		return append(b, 0x90|byte(n))
+	case n <= math.MaxUint16:
+		return append(b, 0xdc, byte(n>>8), byte(n))
+	default:
+		return append(b, 0xdd, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+}
+
+func appendMapHeader(b []byte, n int) []byte {
+	switch {
+	case n < 16:
+		return append(b, 0x80|byte(n))
+	case n <= math.MaxUint16:
+		return append(b, 0xde, byte(n>>8), byte(n))
+	default:
+		return append(b, 0xdf, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+}
+
+func appendString(b []byte, s string) []byte {
+	n := len(s)
+	switch {
+	case n < 32:
+		b = append(b, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		b = append(b, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		b = append(b, 0xda, byte(n>>8), byte(n))
+	default:
+		b = append(b, 0xdb, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+	return append(b, s...)
+}
+
+func appendBinary(b []byte, data []byte) []byte {
+	n := len(data)
+	switch {
+	case n <= math.MaxUint8:
+		b = append(b, 0xc4, byte(n))
+	case n <= math.MaxUint16:
+		b = append(b, 0xc5, byte(n>>8), byte(n))
+	default:
+		b = append(b, 0xc6, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+	return append(b, data...)
+}
+
+func appendInt(b []byte, i int64) []byte {
+	switch {
+	case 0 <= i && i < 128:
+		return append(b, byte(i))
+	case -32 <= i && i < 0:
+		return append(b, byte(i))
+	case math.MinInt32 <= i && i <= math.MaxInt32:
+		return append(b, 0xd2, byte(i>>24), byte(i>>16), byte(i>>8), byte(i))
+	default:
+		b = append(b, 0xd3)
+		var buf [8]byte
+		binary.BigEndian.PutUint64(buf[:], uint64(i))
+		return append(b, buf[:]...)
+	}
+}
+
+// eventTimeExt is the MessagePack extension type of a Fluent EventTime.
+const eventTimeExt = 0
+
+// appendEventTime appends t as a Fluent EventTime:
+// a fixext8 holding big-endian 32-bit seconds and nanoseconds.
+func appendEventTime(b []byte, t time.Time) []byte {
+	sec, nsec := uint32(t.Unix()), uint32(t.Nanosecond())
+	return append(b, 0xd7, eventTimeExt,
+		byte(sec>>24), byte(sec>>16), byte(sec>>8), byte(sec),
+		byte(nsec>>24), byte(nsec>>16), byte(nsec>>8), byte(nsec))
+}
+
+// extension is a decoded MessagePack extension value.
+type extension struct {
+	Type int8
+	Data []byte
+}
+
+// readValue decodes a single MessagePack value. Arrays are returned as
+// []interface{}, maps as map[string]interface{} (non-string keys are formatted
+// with fmt), strings as string, binary as []byte, integers as int64,
+// and extensions as extension.
+func readValue(r *bufio.Reader) (interface{}, error) {
+	c, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c&0xf0 == 0x80:
+		return readMap(r, int(c&0x0f))
+	case c&0xf0 == 0x90:
+		return readArray(r, int(c&0x0f))
+	case c&0xe0 == 0xa0:
+		return readString(r, int(c&0x1f))
+	}
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := readLength(r, c-0xc4)
+		if err != nil {
+			return nil, err
+		}
+		return readBytes(r, n)
+	case 0xc7, 0xc8, 0xc9:
+		n, err := readLength(r, c-0xc7)
+		if err != nil {
+			return nil, err
+		}
+		return readExtension(r, n)
+	case 0xca:
+		b, err := readBytes(r, 4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), nil
+	case 0xcb:
+		b, err := readBytes(r, 8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		b, err := readBytes(r, 1<<(c-0xcc))
+		if err != nil {
+			return nil, err
+		}
+		return int64(beUint(b)), nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		b, err := readBytes(r, 1<<(c-0xd0))
+		if err != nil {
+			return nil, err
+		}
+		shift := 64 - 8*uint(len(b))
+		return int64(beUint(b)<<shift) >> shift, nil
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return readExtension(r, 1<<(c-0xd4))
+	case 0xd9, 0xda, 0xdb:
+		n, err := readLength(r, c-0xd9)
+		if err != nil {
+			return nil, err
+		}
+		return readString(r, n)
+	case 0xdc, 0xdd:
+		n, err := readLength(r, c-0xdc+1)
+		if err != nil {
+			return nil, err
+		}
+		return readArray(r, n)
+	case 0xde, 0xdf:
+		n, err := readLength(r, c-0xde+1)
+		if err != nil {
+			return nil, err
+		}
+		return readMap(r, n)
+	}
+	return nil, fmt.Errorf("msgpack: unknown type byte %#02x", c)
+}
+
+// readLength reads a big-endian length of 1, 2, or 4 bytes for sizeClass
+// 0, 1, or 2, respectively.
+func readLength(r *bufio.Reader, sizeClass byte) (int, error) {
+	b, err := readBytes(r, 1<<sizeClass)
+	if err != nil {
+		return 0, err
+	}
+	n := beUint(b)
+	if n > math.MaxInt32 {
+		return 0, errors.New("msgpack: length too large")
+	}
+	return int(n), nil
+}
+
+func beUint(b []byte) uint64 {
+	var n uint64
+	for _, c := range b {
+		n = n<<8 | uint64(c)
+	}
+	return n
+}
+
+func readBytes(r *bufio.Reader, n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return b, nil
+}
+
+func readString(r *bufio.Reader, n int) (string, error) {
+	b, err := readBytes(r, n)
+	return string(b), err
+}
+
+func readExtension(r *bufio.Reader, n int) (extension, error) {
+	typ, err := r.ReadByte()
+	if err != nil {
+		return extension{}, err
+	}
+	data, err := readBytes(r, n)
+	return extension{Type: int8(typ), Data: data}, err
+}
+
+func readArray(r *bufio.Reader, n int) ([]interface{}, error) {
+	a := make([]interface{}, 0, n)
+	for i := 0; i < n; i++ {
+		v, err := readValue(r)
+		if err != nil {
+			return nil, err
+		}
+		a = append(a, v)
+	}
+	return a, nil
+}
+
+func readMap(r *bufio.Reader, n int) (map[string]interface{}, error) {
+	m := make(map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		k, err := readValue(r)
+		if err != nil {
+			return nil, err
+		}
+		v, err := readValue(r)
+		if err != nil {
+			return nil, err
+		}
+		if ks, ok := k.(string); ok {
+			m[ks] = v
+		} else {
+			m[fmt.Sprint(k)] = v
+		}
+	}
+	return m, nil
+}
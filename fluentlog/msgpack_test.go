@@ -0,0 +1,72 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package fluentlog
+
+import (
+	"bufio"
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMsgpackRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		encode func([]byte) []byte
+		want   interface{}
+	}{
+		{"FixInt", func(b []byte) []byte { return appendInt(b, 42) }, int64(42)},
+		{"NegFixInt", func(b []byte) []byte { return appendInt(b, -3) }, int64(-3)},
+		{"Int32", func(b []byte) []byte { return appendInt(b, -100000) }, int64(-100000)},
+		{"Int64", func(b []byte) []byte { return appendInt(b, math.MaxInt64) }, int64(math.MaxInt64)},
+		{"FixStr", func(b []byte) []byte { return appendString(b, "foo") }, "foo"},
+		{"Str8", func(b []byte) []byte { return appendString(b, strings.Repeat("x", 200)) }, strings.Repeat("x", 200)},
+		{"Str16", func(b []byte) []byte { return appendString(b, strings.Repeat("x", 1000)) }, strings.Repeat("x", 1000)},
+		{"Bin", func(b []byte) []byte { return appendBinary(b, []byte{1, 2, 3}) }, []byte{1, 2, 3}},
+		{
+			name: "Array",
+			encode: func(b []byte) []byte {
+				b = appendArrayHeader(b, 2)
+				b = appendString(b, "a")
+				return appendInt(b, 1)
+			},
+			want: []interface{}{"a", int64(1)},
+		},
+		{
+			name: "Map",
+			encode: func(b []byte) []byte {
+				b = appendMapHeader(b, 1)
+				b = appendString(b, "ack")
+				return appendString(b, "xyzzy")
+			},
+			want: map[string]interface{}{"ack": "xyzzy"},
+		},
+		{
+			name: "EventTime",
+			encode: func(b []byte) []byte {
+				return appendEventTime(b, time.Unix(1581452772, 123456789))
+			},
+			want: extension{Type: eventTimeExt, Data: []byte{0x5e, 0x43, 0x0d, 0xe4, 0x07, 0x5b, 0xcd, 0x15}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := test.encode(nil)
+			got, err := readValue(bufio.NewReader(bytes.NewReader(b)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("decoded (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
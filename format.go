@@ -13,40 +13,40 @@ import (
 	"time"
 )
 
-// Infof writes an info message to the default Logger.  Its arguments are handled in the manner of fmt.Sprintf.
+// Infof writes an info message to the Logger in ctx or the default Logger.  Its arguments are handled in the manner of fmt.Sprintf.
 func Infof(ctx context.Context, format string, args ...interface{}) {
 	if false {
 		// Enable printf checking in go vet.
 		_ = fmt.Sprintf(format, args...)
 	}
-	logf(ctx, Default(), Info, format, args)
+	logf(ctx, FromContext(ctx), Info, format, args)
 }
 
-// Debugf writes a debug message to the default Logger.  Its arguments are handled in the manner of fmt.Sprintf.
+// Debugf writes a debug message to the Logger in ctx or the default Logger.  Its arguments are handled in the manner of fmt.Sprintf.
 func Debugf(ctx context.Context, format string, args ...interface{}) {
 	if false {
 		// Enable printf checking in go vet.
 		_ = fmt.Sprintf(format, args...)
 	}
-	logf(ctx, Default(), Debug, format, args)
+	logf(ctx, FromContext(ctx), Debug, format, args)
 }
 
-// Warnf writes a warning message to the default Logger.  Its arguments are handled in the manner of fmt.Sprintf.
+// Warnf writes a warning message to the Logger in ctx or the default Logger.  Its arguments are handled in the manner of fmt.Sprintf.
 func Warnf(ctx context.Context, format string, args ...interface{}) {
 	if false {
 		// Enable printf checking in go vet.
 		_ = fmt.Sprintf(format, args...)
 	}
-	logf(ctx, Default(), Warn, format, args)
+	logf(ctx, FromContext(ctx), Warn, format, args)
 }
 
-// Errorf writes an error message to the default Logger.  Its arguments are handled in the manner of fmt.Sprintf.
+// Errorf writes an error message to the Logger in ctx or the default Logger.  Its arguments are handled in the manner of fmt.Sprintf.
 func Errorf(ctx context.Context, format string, args ...interface{}) {
 	if false {
 		// Enable printf checking in go vet.
 		_ = fmt.Sprintf(format, args...)
 	}
-	logf(ctx, Default(), Error, format, args)
+	logf(ctx, FromContext(ctx), Error, format, args)
 }
 
 // Logf writes a message to a Logger.  Its arguments are handled in the manner of fmt.Sprintf.
@@ -64,7 +64,7 @@ func logf(ctx context.Context, logger Logger, level Level, format string, args [
 		ent.File = file
 		ent.Line = line
 	}
-	if !logger.LogEnabled(ent) {
+	if !LogEnabledContext(ctx, logger, ent) {
 		return
 	}
 	ent.Msg = fmt.Sprintf(format, args...)
@@ -76,10 +76,20 @@ func logf(ctx context.Context, logger Logger, level Level, format string, args [
 
 // IsEnabled reports false if the default logger will no-op for logs at the given level.
 func IsEnabled(level Level) bool {
+	return isEnabled(context.Background(), Default(), level)
+}
+
+// IsEnabledContext reports false if the Logger in ctx or the default logger
+// will no-op for logs at the given level.
+func IsEnabledContext(ctx context.Context, level Level) bool {
+	return isEnabled(ctx, FromContext(ctx), level)
+}
+
+func isEnabled(ctx context.Context, logger Logger, level Level) bool {
 	ent := Entry{Time: time.Now(), Level: level}
-	if _, file, line, ok := runtime.Caller(1); ok {
+	if _, file, line, ok := runtime.Caller(2); ok {
 		ent.File = file
 		ent.Line = line
 	}
-	return Default().LogEnabled(ent)
+	return LogEnabledContext(ctx, logger, ent)
 }
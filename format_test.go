@@ -92,3 +92,30 @@ func BenchmarkWriterLogf(b *testing.B) {
 		Logf(ctx, logger, Info, "Hello, %v!", "World")
 	}
 }
+
+func TestLogfContextEnabler(t *testing.T) {
+	type debugKey struct{}
+	ce := &contextEnabler{key: debugKey{}}
+	formatted := false
+	arg := stringerFunc(func() string {
+		formatted = true
+		return "World"
+	})
+	ctx := context.Background()
+	Logf(ctx, ce, Info, "Hello, %v!", arg)
+	if formatted {
+		t.Error("Logf formatted its arguments when LogEnabledContext returned false")
+	}
+	if len(ce.entries) > 0 {
+		t.Errorf("Logf logged %d entries when LogEnabledContext returned false", len(ce.entries))
+	}
+
+	Logf(context.WithValue(ctx, debugKey{}, true), ce, Info, "Hello, %v!", arg)
+	if len(ce.entries) != 1 || ce.entries[0].Msg != "Hello, World!" {
+		t.Errorf("entries = %+v; want one entry with message %q", ce.entries, "Hello, World!")
+	}
+}
+
+type stringerFunc func() string
+
+func (f stringerFunc) String() string { return f() }
@@ -0,0 +1,38 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package httplog_test
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"zombiezen.com/go/log"
+	"zombiezen.com/go/log/httplog"
+)
+
+func ExampleNewHandler() {
+	log.SetDefault(&log.LevelFilter{
+		Min:    log.Info,
+		Output: log.New(os.Stderr, "", log.StdFlags, nil),
+	})
+	key := []byte(os.Getenv("DEBUG_LOG_KEY"))
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		// Only logged for requests with a valid X-Debug-Log header.
+		log.Debugf(r.Context(), "Handling %s %s", r.Method, r.URL.Path)
+		fmt.Fprintln(w, "Hello, World!")
+	})
+	http.ListenAndServe(":8080", httplog.NewHandler(mux, key, nil))
+}
+
+func ExampleSign() {
+	// Produce a header value that enables Debug entries for the next hour.
+	key := []byte(os.Getenv("DEBUG_LOG_KEY"))
+	fmt.Printf("%s: %s\n", httplog.DefaultHeader, httplog.Sign(key, time.Now().Add(time.Hour)))
+}
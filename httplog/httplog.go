@@ -0,0 +1,138 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package httplog provides HTTP middleware that enables Debug logging for
+// individual requests that carry a signed header.
+package httplog
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"zombiezen.com/go/log"
+)
+
+// DefaultHeader is the request header checked if Options.Header is empty.
+const DefaultHeader = "X-Debug-Log"
+
+// DefaultMaxTTL is the maximum token lifetime used if Options.MaxTTL is zero.
+const DefaultMaxTTL = time.Hour
+
+// Options is the set of optional arguments to NewHandler.
+type Options struct {
+	// Header is the name of the request header that carries the signed token.
+	// If empty, DefaultHeader is used.
+	Header string
+
+	// MaxTTL is the longest time before its expiry that a token is accepted.
+	// Tokens that expire further in the future are rejected, which limits
+	// how long a leaked token can be used. If zero, DefaultMaxTTL is used.
+	MaxTTL time.Duration
+
+	// Now returns the current time. If nil, time.Now is used.
+	Now func() time.Time
+}
+
+// NewHandler returns a handler that calls h. If a request carries a header
+// value returned by Sign with the same key that has not expired, the request's
+// Context is marked with log.WithMinLevel so that LevelFilters let Debug
+// entries through for that request only. Requests with a missing, invalid,
+// or expired header are passed to h unchanged. NewHandler panics if key
+// is empty.
+//
+// A token is not bound to a particular request: anyone who has a token can
+// enable Debug entries for any number of requests until it expires.
+// Keep tokens short-lived; Options.MaxTTL bounds their lifetime.
+//
+// The header is removed from the request before it is passed to h,
+// so that it is not forwarded to other servers.
+func NewHandler(h http.Handler, key []byte, opts *Options) http.Handler {
+	if len(key) == 0 {
+		panic("httplog.NewHandler: empty key")
+	}
+	handler := &debugHandler{
+		h:      h,
+		key:    append([]byte(nil), key...),
+		header: DefaultHeader,
+		maxTTL: DefaultMaxTTL,
+		now:    time.Now,
+	}
+	if opts != nil {
+		if opts.Header != "" {
+			handler.header = http.CanonicalHeaderKey(opts.Header)
+		}
+		if opts.MaxTTL > 0 {
+			handler.maxTTL = opts.MaxTTL
+		}
+		if opts.Now != nil {
+			handler.now = opts.Now
+		}
+	}
+	return handler
+}
+
+type debugHandler struct {
+	h      http.Handler
+	key    []byte
+	header string
+	maxTTL time.Duration
+	now    func() time.Time
+}
+
+func (d *debugHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get(d.header)
+	if token == "" {
+		d.h.ServeHTTP(w, r)
+		return
+	}
+	r = r.Clone(r.Context())
+	r.Header.Del(d.header)
+	if verify(d.key, token, d.now(), d.maxTTL) {
+		r = r.WithContext(log.WithMinLevel(r.Context(), log.Debug))
+	}
+	d.h.ServeHTTP(w, r)
+}
+
+// Sign returns a header value for NewHandler that is valid until expires.
+// NewHandler rejects the value if expires is further in the future than
+// Options.MaxTTL.
+// The value has the form "EXPIRES.SIGNATURE", where EXPIRES is a Unix time
+// in seconds and SIGNATURE is the hex-encoded HMAC-SHA256 of EXPIRES.
+func Sign(key []byte, expires time.Time) string {
+	exp := strconv.FormatInt(expires.Unix(), 10)
+	return exp + "." + hex.EncodeToString(mac(key, exp))
+}
+
+// verify reports whether token was returned by Sign with key,
+// has not expired at now, and expires within maxTTL of now.
+func verify(key []byte, token string, now time.Time, maxTTL time.Duration) bool {
+	i := strings.IndexByte(token, '.')
+	if i < 0 {
+		return false
+	}
+	exp, sig := token[:i], token[i+1:]
+	got, err := hex.DecodeString(sig)
+	if err != nil || !hmac.Equal(got, mac(key, exp)) {
+		return false
+	}
+	sec, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil {
+		return false
+	}
+	expires := time.Unix(sec, 0)
+	return now.Before(expires) && !expires.After(now.Add(maxTTL))
+}
+
+func mac(key []byte, msg string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(msg))
+	return h.Sum(nil)
+}
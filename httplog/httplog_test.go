@@ -0,0 +1,117 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package httplog
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"zombiezen.com/go/log"
+)
+
+func TestNewHandler(t *testing.T) {
+	key := []byte("secret")
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	valid := Sign(key, now.Add(time.Minute))
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		want   bool
+	}{
+		{name: "NoHeader"},
+		{name: "Valid", header: DefaultHeader, value: valid, want: true},
+		{name: "Expired", header: DefaultHeader, value: Sign(key, now.Add(-time.Second))},
+		{name: "WrongKey", header: DefaultHeader, value: Sign([]byte("guess"), now.Add(time.Minute))},
+		{name: "TamperedExpiry", header: DefaultHeader, value: "9" + valid},
+		{name: "NoSignature", header: DefaultHeader, value: strings.Split(valid, ".")[0]},
+		{name: "BadHex", header: DefaultHeader, value: strings.Split(valid, ".")[0] + ".zz"},
+		{name: "OtherHeader", header: "X-Debug", value: valid},
+		{name: "AtMaxTTL", header: DefaultHeader, value: Sign(key, now.Add(DefaultMaxTTL)), want: true},
+		{name: "BeyondMaxTTL", header: DefaultHeader, value: Sign(key, now.Add(DefaultMaxTTL+time.Second))},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter := &log.LevelFilter{Min: log.Info, Output: log.New(ioutil.Discard, "", 0, nil)}
+			called := false
+			var got bool
+			var forwarded string
+			h := NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				got = log.LogEnabledContext(r.Context(), filter, log.Entry{Level: log.Debug})
+				forwarded = r.Header.Get(DefaultHeader)
+			}), key, &Options{
+				Now: func() time.Time { return now },
+			})
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if test.header != "" {
+				req.Header.Set(test.header, test.value)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), req)
+			if !called {
+				t.Fatal("handler not called")
+			}
+			if got != test.want {
+				t.Errorf("Debug enabled = %t; want %t", got, test.want)
+			}
+			if forwarded != "" {
+				t.Errorf("%s header = %q; want it removed", DefaultHeader, forwarded)
+			}
+		})
+	}
+}
+
+func TestNewHandlerCustomHeader(t *testing.T) {
+	key := []byte("secret")
+	filter := &log.LevelFilter{Min: log.Info, Output: log.New(ioutil.Discard, "", 0, nil)}
+	var got bool
+	h := NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = log.LogEnabledContext(r.Context(), filter, log.Entry{Level: log.Debug})
+	}), key, &Options{Header: "x-trace-debug"})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Trace-Debug", Sign(key, time.Now().Add(time.Minute)))
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	if !got {
+		t.Error("Debug not enabled for request with custom header")
+	}
+}
+
+func TestNewHandlerMaxTTL(t *testing.T) {
+	key := []byte("secret")
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	filter := &log.LevelFilter{Min: log.Info, Output: log.New(ioutil.Discard, "", 0, nil)}
+	var got bool
+	h := NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = log.LogEnabledContext(r.Context(), filter, log.Entry{Level: log.Debug})
+	}), key, &Options{
+		MaxTTL: 24 * time.Hour,
+		Now:    func() time.Time { return now },
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(DefaultHeader, Sign(key, now.Add(12*time.Hour)))
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	if !got {
+		t.Error("Debug not enabled for token within MaxTTL")
+	}
+}
+
+func TestNewHandlerEmptyKey(t *testing.T) {
+	for _, key := range [][]byte{nil, {}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewHandler(h, %q, nil) did not panic", key)
+				}
+			}()
+			NewHandler(http.NotFoundHandler(), key, nil)
+		}()
+	}
+}
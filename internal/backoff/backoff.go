@@ -0,0 +1,54 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package backoff computes delays between attempts of a network operation.
+package backoff
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Default values for the fields of Policy.
+const (
+	DefaultInitial = 100 * time.Millisecond
+	DefaultMax     = 30 * time.Second
+)
+
+// Policy is a jittered exponential backoff policy.
+type Policy struct {
+	// Initial is the delay before the first retry.
+	// If zero, DefaultInitial is used.
+	Initial time.Duration
+	// Max is the upper bound on any delay.
+	// If zero, DefaultMax is used.
+	Max time.Duration
+}
+
+// Delay returns the duration to wait before retry n, where n starts at 1.
+// The delay doubles with each retry and is randomized to be between half and
+// all of the nominal delay so that many clients do not retry in lockstep.
+func (p Policy) Delay(n int) time.Duration {
+	initial, max := p.Initial, p.Max
+	if initial <= 0 {
+		initial = DefaultInitial
+	}
+	if max <= 0 {
+		max = DefaultMax
+	}
+	if initial > max {
+		initial = max
+	}
+	d := initial
+	for i := 1; i < n && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
@@ -0,0 +1,36 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package backoff
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDelay(t *testing.T) {
+	p := Policy{Initial: 100 * time.Millisecond, Max: time.Second}
+	tests := []struct {
+		n       int
+		nominal time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{100, time.Second},
+	}
+	for _, test := range tests {
+		for i := 0; i < 20; i++ {
+			got := p.Delay(test.n)
+			if got < test.nominal/2 || got > test.nominal {
+				t.Errorf("Delay(%d) = %v; want in [%v, %v]", test.n, got, test.nominal/2, test.nominal)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,367 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package batch provides a queue that delivers log records to a remote sink
+// in groups from a background goroutine.
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"zombiezen.com/go/log/internal/backoff"
+)
+
+// Default values for the fields of Options.
+const (
+	DefaultBatchSize = 512
+	DefaultInterval  = time.Second
+	DefaultMaxBuffer = 2048
+)
+
+// Options is the set of arguments to New.
+type Options struct {
+	// Send delivers a batch of items in the order they were added.
+	// Send must not retain the slice after it returns.
+	// Errors returned from Send are retried unless wrapped with Permanent.
+	Send func(ctx context.Context, items []interface{}) error
+
+	// BatchSize is the maximum number of items passed to a single call of Send.
+	// If zero, DefaultBatchSize is used.
+	BatchSize int
+	// Interval is the maximum amount of time an item waits in the queue
+	// before a partial batch is sent. If zero, DefaultInterval is used.
+	Interval time.Duration
+	// MaxBuffer is the maximum number of items waiting to be sent.
+	// When the buffer is full, the oldest items are dropped.
+	// If zero, DefaultMaxBuffer is used.
+	MaxBuffer int
+	// MaxBufferBytes is the maximum sum of item sizes waiting to be sent.
+	// When exceeded, the oldest items are dropped. If zero, there is no limit.
+	MaxBufferBytes int
+
+	// MaxAttempts is the number of times Send is called for a batch before
+	// it is dropped. If zero, batches are retried until the queue is closed.
+	MaxAttempts int
+	// Backoff determines the delay between attempts.
+	Backoff backoff.Policy
+
+	// ErrorFunc is called if not nil when items are dropped.
+	// It must be safe to call from multiple goroutines.
+	ErrorFunc func(context.Context, error)
+}
+
+// A Queue buffers items and sends them in batches.
+// It is safe to call methods on a Queue from multiple goroutines.
+type Queue struct {
+	send        func(context.Context, []interface{}) error
+	batchSize   int
+	interval    time.Duration
+	maxBuffer   int
+	maxBytes    int
+	maxAttempts int
+	backoff     backoff.Policy
+	errFunc     func(context.Context, error)
+
+	wake    chan struct{}
+	quit    chan struct{}
+	stopped chan struct{}
+
+	mu            sync.Mutex
+	items         []item
+	bytes         int
+	added         uint64 // sequence number of the next item
+	inflightStart uint64 // sequence number of first item in the batch being sent
+	inflight      int    // number of items in the batch being sent
+	dropped       uint64
+	flushTarget   uint64
+	closed        bool
+	progress      chan struct{} // closed whenever items are sent or dropped
+}
+
+type item struct {
+	v    interface{}
+	size int
+	seq  uint64
+}
+
+// New returns a new Queue and starts its background goroutine.
+// Call Close to stop the goroutine.
+func New(opts Options) *Queue {
+	q := &Queue{
+		send:        opts.Send,
+		batchSize:   opts.BatchSize,
+		interval:    opts.Interval,
+		maxBuffer:   opts.MaxBuffer,
+		maxBytes:    opts.MaxBufferBytes,
+		maxAttempts: opts.MaxAttempts,
+		backoff:     opts.Backoff,
+		errFunc:     opts.ErrorFunc,
+		wake:        make(chan struct{}, 1),
+		quit:        make(chan struct{}),
+		stopped:     make(chan struct{}),
+		progress:    make(chan struct{}),
+	}
+	if q.batchSize <= 0 {
+		q.batchSize = DefaultBatchSize
+	}
+	if q.interval <= 0 {
+		q.interval = DefaultInterval
+	}
+	if q.maxBuffer <= 0 {
+		q.maxBuffer = DefaultMaxBuffer
+	}
+	go q.run()
+	return q
+}
+
+// Add appends an item to the queue. size is the item's contribution toward
+// Options.MaxBufferBytes. ctx is passed to Options.ErrorFunc if adding the
+// item causes older items to be dropped. Items added after Close are ignored.
+func (q *Queue) Add(ctx context.Context, v interface{}, size int) {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return
+	}
+	q.items = append(q.items, item{v: v, size: size, seq: q.added})
+	q.added++
+	q.bytes += size
+	evicted := 0
+	for len(q.items) > 1 && (len(q.items) > q.maxBuffer || (q.maxBytes > 0 && q.bytes > q.maxBytes)) {
+		q.bytes -= q.items[0].size
+		q.items[0] = item{}
+		q.items = q.items[1:]
+		evicted++
+	}
+	if evicted > 0 {
+		q.dropped += uint64(evicted)
+		q.notifyLocked()
+	}
+	full := len(q.items) >= q.batchSize
+	q.mu.Unlock()
+
+	if full {
+		q.signal()
+	}
+	if evicted > 0 && q.errFunc != nil {
+		q.errFunc(ctx, fmt.Errorf("buffer full: dropped %d entries", evicted))
+	}
+}
+
+// Flush sends all items added before the call to Flush without waiting for
+// Options.Interval to elapse. Flush returns an error if any items were dropped
+// while it was waiting or if ctx is done before the items are handled.
+func (q *Queue) Flush(ctx context.Context) error {
+	q.mu.Lock()
+	target := q.added
+	droppedStart := q.dropped
+	if q.flushTarget < target {
+		q.flushTarget = target
+	}
+	q.mu.Unlock()
+	q.signal()
+
+	for {
+		q.mu.Lock()
+		done := q.lowLocked() >= target
+		dropped := q.dropped - droppedStart
+		progress := q.progress
+		q.mu.Unlock()
+		if done {
+			if dropped > 0 {
+				return fmt.Errorf("flush: %d entries dropped", dropped)
+			}
+			return nil
+		}
+		select {
+		case <-progress:
+		case <-q.stopped:
+		case <-ctx.Done():
+			return fmt.Errorf("flush: %w", ctx.Err())
+		}
+	}
+}
+
+// Close stops accepting new items, makes a final attempt to send any buffered
+// items, and waits for the background goroutine to exit. Close returns an
+// error if any items buffered at the time of the call could not be sent.
+func (q *Queue) Close() error {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		<-q.stopped
+		return nil
+	}
+	q.closed = true
+	droppedStart := q.dropped
+	q.mu.Unlock()
+
+	close(q.quit)
+	<-q.stopped
+	q.mu.Lock()
+	dropped := q.dropped - droppedStart
+	q.mu.Unlock()
+	if dropped > 0 {
+		return fmt.Errorf("close: %d entries dropped", dropped)
+	}
+	return nil
+}
+
+// lowLocked returns the sequence number of the oldest item that has not been
+// sent or dropped.
+func (q *Queue) lowLocked() uint64 {
+	switch {
+	case q.inflight > 0:
+		return q.inflightStart
+	case len(q.items) > 0:
+		return q.items[0].seq
+	default:
+		return q.added
+	}
+}
+
+func (q *Queue) notifyLocked() {
+	close(q.progress)
+	q.progress = make(chan struct{})
+}
+
+func (q *Queue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (q *Queue) run() {
+	defer close(q.stopped)
+	ticker := time.NewTicker(q.interval)
+	defer ticker.Stop()
+	drain := false
+	for {
+		q.mu.Lock()
+		n := len(q.items)
+		closed := q.closed
+		urgent := closed || q.lowLocked() < q.flushTarget
+		q.mu.Unlock()
+		if n == 0 {
+			drain = false
+			if closed {
+				return
+			}
+		} else if drain || urgent || n >= q.batchSize {
+			q.sendBatch()
+			continue
+		}
+
+		select {
+		case <-q.wake:
+		case <-ticker.C:
+			drain = true
+		case <-q.quit:
+		}
+	}
+}
+
+func (q *Queue) sendBatch() {
+	q.mu.Lock()
+	n := len(q.items)
+	if n > q.batchSize {
+		n = q.batchSize
+	}
+	batch := make([]interface{}, n)
+	q.inflightStart = q.items[0].seq
+	q.inflight = n
+	for i := range batch {
+		batch[i] = q.items[i].v
+		q.bytes -= q.items[i].size
+		q.items[i] = item{}
+	}
+	q.items = q.items[n:]
+	q.mu.Unlock()
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = q.send(context.Background(), batch)
+		if err == nil || isPermanent(err) || q.isClosed() || (q.maxAttempts > 0 && attempt >= q.maxAttempts) {
+			break
+		}
+		delay := q.backoff.Delay(attempt)
+		if d, ok := retryAfter(err); ok && d > delay {
+			delay = d
+		}
+		t := time.NewTimer(delay)
+		select {
+		case <-t.C:
+		case <-q.quit:
+			t.Stop()
+		}
+	}
+
+	q.mu.Lock()
+	q.inflight = 0
+	if err != nil {
+		q.dropped += uint64(n)
+	}
+	q.notifyLocked()
+	q.mu.Unlock()
+	if err != nil && q.errFunc != nil {
+		q.errFunc(context.Background(), fmt.Errorf("dropped %d entries: %w", n, err))
+	}
+}
+
+func (q *Queue) isClosed() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.closed
+}
+
+// Permanent wraps err to indicate that the failed Send should not be retried.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err}
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+func isPermanent(err error) bool {
+	return errors.As(err, new(permanentError))
+}
+
+// RetryAfter wraps err to indicate that the failed Send should not be
+// retried for at least d, as with an HTTP Retry-After header.
+func RetryAfter(err error, d time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return retryAfterError{err, d}
+}
+
+type retryAfterError struct {
+	err   error
+	delay time.Duration
+}
+
+func (e retryAfterError) Error() string { return e.err.Error() }
+func (e retryAfterError) Unwrap() error { return e.err }
+
+func retryAfter(err error) (time.Duration, bool) {
+	var e retryAfterError
+	if !errors.As(err, &e) {
+		return 0, false
+	}
+	return e.delay, true
+}
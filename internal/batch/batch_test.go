@@ -0,0 +1,227 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package batch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"zombiezen.com/go/log/internal/backoff"
+)
+
+func TestQueue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Order", func(t *testing.T) {
+		rec := new(recorder)
+		q := New(Options{
+			Send:      rec.send,
+			BatchSize: 3,
+			Interval:  time.Hour,
+		})
+		for i := 0; i < 7; i++ {
+			q.Add(ctx, i, 1)
+		}
+		if err := q.Flush(ctx); err != nil {
+			t.Error("Flush:", err)
+		}
+		if err := q.Close(); err != nil {
+			t.Error("Close:", err)
+		}
+		want := [][]interface{}{{0, 1, 2}, {3, 4, 5}, {6}}
+		if diff := cmp.Diff(want, rec.batches()); diff != "" {
+			t.Errorf("batches (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Interval", func(t *testing.T) {
+		rec := new(recorder)
+		q := New(Options{
+			Send:     rec.send,
+			Interval: 10 * time.Millisecond,
+		})
+		defer q.Close()
+		q.Add(ctx, "x", 1)
+		deadline := time.Now().Add(5 * time.Second)
+		for len(rec.batches()) == 0 {
+			if time.Now().After(deadline) {
+				t.Fatal("partial batch not sent after interval")
+			}
+			time.Sleep(time.Millisecond)
+		}
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		rec := &recorder{failures: 2}
+		q := New(Options{
+			Send:     rec.send,
+			Interval: time.Hour,
+			Backoff:  backoff.Policy{Initial: time.Millisecond},
+		})
+		q.Add(ctx, "x", 1)
+		if err := q.Flush(ctx); err != nil {
+			t.Error("Flush:", err)
+		}
+		q.Close()
+		if got := rec.attempts(); got != 3 {
+			t.Errorf("Send called %d times; want 3", got)
+		}
+		if diff := cmp.Diff([][]interface{}{{"x"}}, rec.batches()); diff != "" {
+			t.Errorf("batches (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("MaxAttempts", func(t *testing.T) {
+		rec := &recorder{failures: 100}
+		var mu sync.Mutex
+		var errs []error
+		q := New(Options{
+			Send:        rec.send,
+			Interval:    time.Hour,
+			MaxAttempts: 2,
+			Backoff:     backoff.Policy{Initial: time.Millisecond},
+			ErrorFunc: func(_ context.Context, err error) {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			},
+		})
+		q.Add(ctx, "x", 1)
+		if err := q.Flush(ctx); err == nil {
+			t.Error("Flush did not return an error")
+		}
+		q.Close()
+		if got := rec.attempts(); got != 2 {
+			t.Errorf("Send called %d times; want 2", got)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if len(errs) != 1 || !errors.Is(errs[0], errSend) {
+			t.Errorf("ErrorFunc called with %v; want [%v]", errs, errSend)
+		}
+	})
+
+	t.Run("Permanent", func(t *testing.T) {
+		rec := &recorder{failures: 100, permanent: true}
+		q := New(Options{
+			Send:     rec.send,
+			Interval: time.Hour,
+			Backoff:  backoff.Policy{Initial: time.Millisecond},
+		})
+		q.Add(ctx, "x", 1)
+		if err := q.Flush(ctx); err == nil {
+			t.Error("Flush did not return an error")
+		}
+		q.Close()
+		if got := rec.attempts(); got != 1 {
+			t.Errorf("Send called %d times; want 1", got)
+		}
+	})
+
+	t.Run("Evict", func(t *testing.T) {
+		rec := new(recorder)
+		errCount := 0
+		q := New(Options{
+			Send:           rec.send,
+			Interval:       time.Hour,
+			MaxBuffer:      3,
+			MaxBufferBytes: 25,
+			ErrorFunc: func(context.Context, error) {
+				errCount++
+			},
+		})
+		for i := 0; i < 5; i++ {
+			q.Add(ctx, i, 10)
+		}
+		if errCount != 3 {
+			t.Errorf("ErrorFunc called %d times; want 3", errCount)
+		}
+		if err := q.Flush(ctx); err != nil {
+			t.Error("Flush:", err)
+		}
+		q.Close()
+		if diff := cmp.Diff([][]interface{}{{3, 4}}, rec.batches()); diff != "" {
+			t.Errorf("batches (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("CloseSendsBuffered", func(t *testing.T) {
+		rec := new(recorder)
+		q := New(Options{
+			Send:     rec.send,
+			Interval: time.Hour,
+		})
+		q.Add(ctx, "x", 1)
+		if err := q.Close(); err != nil {
+			t.Error("Close:", err)
+		}
+		q.Add(ctx, "y", 1)
+		if diff := cmp.Diff([][]interface{}{{"x"}}, rec.batches()); diff != "" {
+			t.Errorf("batches (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("CloseStopsRetrying", func(t *testing.T) {
+		rec := &recorder{failures: 1000}
+		q := New(Options{
+			Send:     rec.send,
+			Interval: time.Hour,
+			Backoff:  backoff.Policy{Initial: time.Hour},
+		})
+		q.Add(ctx, "x", 1)
+		flushCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		err := q.Flush(flushCtx)
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Flush(...) = %v; want %v", err, context.DeadlineExceeded)
+		}
+		if err := q.Close(); err == nil {
+			t.Error("Close did not report dropped entries")
+		}
+	})
+}
+
+var errSend = errors.New("bork")
+
+type recorder struct {
+	mu        sync.Mutex
+	failures  int
+	permanent bool
+	calls     int
+	sent      [][]interface{}
+}
+
+func (r *recorder) send(ctx context.Context, items []interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls++
+	if r.failures > 0 {
+		r.failures--
+		if r.permanent {
+			return Permanent(errSend)
+		}
+		return errSend
+	}
+	r.sent = append(r.sent, append([]interface{}(nil), items...))
+	return nil
+}
+
+func (r *recorder) attempts() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.calls
+}
+
+func (r *recorder) batches() [][]interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]interface{}(nil), r.sent...)
+}
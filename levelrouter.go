@@ -0,0 +1,107 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package log
+
+import (
+	"context"
+	"sort"
+)
+
+// A LevelRoute sends entries at or above Min to Output.
+// See NewLevelRouter for how routes are chosen.
+type LevelRoute struct {
+	Min    Level
+	Output Logger
+}
+
+// A LevelRouter is a Logger that sends each entry to Loggers chosen by the
+// entry's level. It is safe to call a LevelRouter's methods from multiple
+// goroutines.
+type LevelRouter struct {
+	// groups is sorted by descending min.
+	groups []levelGroup
+}
+
+type levelGroup struct {
+	min     Level
+	outputs []Logger
+}
+
+// NewLevelRouter returns a LevelRouter for the given routes. Each entry is
+// sent to every route with the greatest Min that is less than or equal to the
+// entry's level, so a route covers the levels up to the next greater Min.
+// Entries below every route's Min are dropped. For example:
+//
+//	log.NewLevelRouter(
+//		log.LevelRoute{Min: log.Error, Output: stderr},
+//		log.LevelRoute{Min: log.Error, Output: pager},
+//		log.LevelRoute{Min: log.Info, Output: stdout},
+//		log.LevelRoute{Min: log.Debug, Output: debugFile},
+//	)
+//
+// sends Error entries to stderr and pager, Info and Warn entries to stdout,
+// and Debug entries to debugFile.
+func NewLevelRouter(routes ...LevelRoute) *LevelRouter {
+	routes = append([]LevelRoute(nil), routes...)
+	sort.SliceStable(routes, func(i, j int) bool {
+		return routes[i].Min > routes[j].Min
+	})
+	r := new(LevelRouter)
+	for _, route := range routes {
+		if route.Output == nil {
+			panic("log.NewLevelRouter: nil Output")
+		}
+		if n := len(r.groups); n > 0 && r.groups[n-1].min == route.Min {
+			r.groups[n-1].outputs = append(r.groups[n-1].outputs, route.Output)
+			continue
+		}
+		r.groups = append(r.groups, levelGroup{
+			min:     route.Min,
+			outputs: []Logger{route.Output},
+		})
+	}
+	return r
+}
+
+// Log sends the entry to the Loggers of the matching routes.
+func (r *LevelRouter) Log(ctx context.Context, e Entry) {
+	for _, out := range r.outputs(e.Level) {
+		out.Log(ctx, e)
+	}
+}
+
+// LogEnabled reports whether any of the Loggers of the matching routes
+// are enabled for the entry.
+func (r *LevelRouter) LogEnabled(e Entry) bool {
+	for _, out := range r.outputs(e.Level) {
+		if out.LogEnabled(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// LogEnabledContext is like LogEnabled, but checks the Loggers with
+// LogEnabledContext.
+func (r *LevelRouter) LogEnabledContext(ctx context.Context, e Entry) bool {
+	for _, out := range r.outputs(e.Level) {
+		if LogEnabledContext(ctx, out, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// outputs returns the Loggers of the routes that match level.
+func (r *LevelRouter) outputs(level Level) []Logger {
+	for _, g := range r.groups {
+		if level >= g.min {
+			return g.outputs
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package log
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var _ interface {
+	Logger
+	ContextLogEnabler
+} = new(LevelRouter)
+
+func TestLevelRouter(t *testing.T) {
+	stderr := new(msgLogger)
+	pager := new(msgLogger)
+	stdout := new(msgLogger)
+	debug := new(msgLogger)
+	r := NewLevelRouter(
+		LevelRoute{Min: Info, Output: stdout},
+		LevelRoute{Min: Error, Output: stderr},
+		LevelRoute{Min: Debug, Output: debug},
+		LevelRoute{Min: Error, Output: pager},
+	)
+	ctx := context.Background()
+	for _, level := range []Level{Debug - 1, Debug, Info, Warn, Error, Error + 1} {
+		r.Log(ctx, Entry{Level: level, Msg: level.String()})
+	}
+	tests := []struct {
+		name string
+		l    *msgLogger
+		want []string
+	}{
+		{"Stderr", stderr, []string{Error.String(), (Error + 1).String()}},
+		{"Pager", pager, []string{Error.String(), (Error + 1).String()}},
+		{"Stdout", stdout, []string{Info.String(), Warn.String()}},
+		{"Debug", debug, []string{Debug.String()}},
+	}
+	for _, test := range tests {
+		if diff := cmp.Diff(test.want, test.l.msgs); diff != "" {
+			t.Errorf("%s messages (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
+
+func TestLevelRouterLogEnabled(t *testing.T) {
+	r := NewLevelRouter(
+		LevelRoute{Min: Error, Output: Discard},
+		LevelRoute{Min: Error, Output: new(msgLogger)},
+		LevelRoute{Min: Info, Output: Discard},
+		LevelRoute{Min: Debug, Output: &LevelFilter{Min: Info, Output: new(msgLogger)}},
+	)
+	tests := []struct {
+		level Level
+		want  bool
+	}{
+		{Debug - 1, false},
+		{Debug, false},
+		{Info, false},
+		{Warn, false},
+		{Error, true},
+	}
+	for _, test := range tests {
+		if got := r.LogEnabled(Entry{Level: test.level}); got != test.want {
+			t.Errorf("LogEnabled(Entry{Level: %v}) = %t; want %t", test.level, got, test.want)
+		}
+		ctx := context.Background()
+		if got := r.LogEnabledContext(ctx, Entry{Level: test.level}); got != test.want {
+			t.Errorf("LogEnabledContext(ctx, Entry{Level: %v}) = %t; want %t", test.level, got, test.want)
+		}
+	}
+
+	// The Debug route's filter consults the Context.
+	ctx := WithMinLevel(context.Background(), Debug)
+	if !r.LogEnabledContext(ctx, Entry{Level: Debug}) {
+		t.Error("LogEnabledContext(WithMinLevel(ctx, Debug), Entry{Level: Debug}) = false; want true")
+	}
+}
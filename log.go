@@ -31,6 +31,28 @@ type Logger interface {
 	LogEnabled(Entry) bool
 }
 
+// ContextLogEnabler is an optional interface for a Logger that uses the
+// Context to decide whether to log an entry, like a Logger that enables Debug
+// entries for a particular request. Callers that have a Context should use the
+// LogEnabledContext function instead of calling Logger.LogEnabled.
+//
+// LogEnabledContext returns false if Log will no-op for a particular Entry
+// and Context. It must be safe to call from multiple goroutines.
+type ContextLogEnabler interface {
+	LogEnabledContext(ctx context.Context, ent Entry) bool
+}
+
+// LogEnabledContext returns the result of l.LogEnabledContext(ctx, ent)
+// if l implements ContextLogEnabler or l.LogEnabled(ent) otherwise.
+// Loggers that wrap other Loggers should forward enablement checks with
+// LogEnabledContext and implement ContextLogEnabler themselves.
+func LogEnabledContext(ctx context.Context, l Logger, ent Entry) bool {
+	if ce, ok := l.(ContextLogEnabler); ok {
+		return ce.LogEnabledContext(ctx, ent)
+	}
+	return l.LogEnabled(ent)
+}
+
 // Entry is a single log record.
 type Entry struct {
 	Msg string
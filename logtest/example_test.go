@@ -0,0 +1,35 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package logtest_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"zombiezen.com/go/log"
+	"zombiezen.com/go/log/logtest"
+)
+
+var t *testing.T
+
+func ExampleTestLogger() {
+	// Inside a test. t is a *testing.T.
+	logtest.TestLogger(t, func(t *testing.T) *logtest.Sink {
+		buf := new(bytes.Buffer)
+		return &logtest.Sink{
+			Logger: log.New(buf, "", 0, nil),
+			Entries: func() ([]log.Entry, error) {
+				var entries []log.Entry
+				for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+					entries = append(entries, log.Entry{Msg: line})
+				}
+				return entries, nil
+			},
+		}
+	})
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package logtest provides a conformance test suite for implementations of
+// zombiezen.com/go/log.Logger.
+package logtest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"zombiezen.com/go/log"
+)
+
+// Sink is a Logger under test along with a way to observe what it delivered.
+type Sink struct {
+	// Logger is the Logger under test. For the purposes of the test suite,
+	// it must deliver every entry that it reports as enabled.
+	Logger log.Logger
+
+	// Entries is called once after the test has finished logging. It returns
+	// the entries that Logger delivered, in the order they were delivered.
+	// Entries should flush Logger if it buffers entries. Only the Msg field
+	// of the returned entries is checked, so implementations that write text
+	// may return entries with only Msg set.
+	Entries func() ([]log.Entry, error)
+}
+
+// A Factory returns a new Sink for a single subtest.
+// It may use t to register cleanup or report errors.
+type Factory func(t *testing.T) *Sink
+
+// TestLogger runs the conformance test suite against the Loggers created by
+// factory. The suite checks that:
+//
+//   - entries are delivered in the order Log was called,
+//   - Log and LogEnabled are safe to call from multiple goroutines
+//     (run with -race to detect data races),
+//   - Log delivers entries when the Context is cancelled or past its deadline,
+//   - a trailing newline in Msg is not delivered as part of the message, and
+//   - Log delivers an entry if and only if LogEnabled reports true for it.
+func TestLogger(t *testing.T, factory Factory) {
+	t.Helper()
+	t.Run("Order", func(t *testing.T) { testOrder(t, factory) })
+	t.Run("Concurrent", func(t *testing.T) { testConcurrent(t, factory) })
+	t.Run("CancelledContext", func(t *testing.T) { testCancelledContext(t, factory) })
+	t.Run("TrailingNewline", func(t *testing.T) { testTrailingNewline(t, factory) })
+	t.Run("LogEnabled", func(t *testing.T) { testLogEnabled(t, factory) })
+}
+
+func testOrder(t *testing.T, factory Factory) {
+	sink := factory(t)
+	level := enabledLevel(t, sink.Logger)
+	ctx := context.Background()
+	const n = 100
+	var want []string
+	for i := 0; i < n; i++ {
+		msg := fmt.Sprintf("entry %d", i)
+		want = append(want, msg)
+		sink.Logger.Log(ctx, newEntry(level, msg))
+	}
+	got := messages(t, sink)
+	if !equalStrings(got, want) {
+		t.Errorf("delivered messages = %q; want %q", got, want)
+	}
+}
+
+func testConcurrent(t *testing.T, factory Factory) {
+	sink := factory(t)
+	level := enabledLevel(t, sink.Logger)
+	ctx := context.Background()
+	const (
+		goroutines = 8
+		perRoutine = 50
+	)
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < perRoutine; i++ {
+				ent := newEntry(level, fmt.Sprintf("goroutine %d entry %d", g, i))
+				if sink.Logger.LogEnabled(ent) {
+					sink.Logger.Log(ctx, ent)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	got := messages(t, sink)
+	if len(got) != goroutines*perRoutine {
+		t.Errorf("delivered %d messages; want %d", len(got), goroutines*perRoutine)
+	}
+	// Each goroutine's entries must be delivered in order.
+	next := make([]int, goroutines)
+	for _, msg := range got {
+		var g, i int
+		if _, err := fmt.Sscanf(msg, "goroutine %d entry %d", &g, &i); err != nil || g < 0 || g >= goroutines {
+			t.Errorf("unexpected message %q", msg)
+			continue
+		}
+		if i != next[g] {
+			t.Errorf("message %q delivered out of order; want entry %d next", msg, next[g])
+		}
+		next[g] = i + 1
+	}
+}
+
+func testCancelledContext(t *testing.T, factory Factory) {
+	sink := factory(t)
+	level := enabledLevel(t, sink.Logger)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	sink.Logger.Log(cancelled, newEntry(level, "cancelled"))
+
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	sink.Logger.Log(expired, newEntry(level, "expired"))
+
+	got := messages(t, sink)
+	if want := []string{"cancelled", "expired"}; !equalStrings(got, want) {
+		t.Errorf("delivered messages = %q; want %q", got, want)
+	}
+}
+
+func testTrailingNewline(t *testing.T, factory Factory) {
+	sink := factory(t)
+	level := enabledLevel(t, sink.Logger)
+	ctx := context.Background()
+	sink.Logger.Log(ctx, newEntry(level, "first\n"))
+	sink.Logger.Log(ctx, newEntry(level, "second"))
+
+	got := messages(t, sink)
+	if want := []string{"first", "second"}; !equalStrings(got, want) {
+		t.Errorf("delivered messages = %q; want %q", got, want)
+	}
+}
+
+func testLogEnabled(t *testing.T, factory Factory) {
+	sink := factory(t)
+	ctx := context.Background()
+	levels := []log.Level{log.Debug - 5, log.Debug, log.Info, log.Info + 5, log.Warn, log.Error, log.Error + 5}
+	var want []string
+	for _, level := range levels {
+		ent := newEntry(level, fmt.Sprintf("level %d", level))
+		withoutMsg := ent
+		withoutMsg.Msg = ""
+		if !sink.Logger.LogEnabled(withoutMsg) && sink.Logger.LogEnabled(ent) {
+			t.Errorf("LogEnabled(%v) is false without a message and true with a message", level)
+		}
+		if sink.Logger.LogEnabled(ent) {
+			want = append(want, ent.Msg)
+		}
+		// Log is called regardless to check that it no-ops.
+		sink.Logger.Log(ctx, ent)
+	}
+
+	got := messages(t, sink)
+	if !equalStrings(got, want) {
+		t.Errorf("delivered messages = %q; want %q (the entries that LogEnabled reported as enabled)", got, want)
+	}
+}
+
+// enabledLevel returns the lowest predefined level that the Logger reports
+// as enabled. It skips the test if none of the levels are enabled.
+func enabledLevel(t *testing.T, l log.Logger) log.Level {
+	t.Helper()
+	for _, level := range []log.Level{log.Info, log.Warn, log.Error} {
+		if l.LogEnabled(newEntry(level, "")) {
+			return level
+		}
+	}
+	t.Skip("Logger does not enable Info, Warn, or Error entries")
+	return 0
+}
+
+func newEntry(level log.Level, msg string) log.Entry {
+	return log.Entry{
+		Msg:   msg,
+		Time:  time.Now(),
+		Level: level,
+		File:  "logtest.go",
+		Line:  1,
+	}
+}
+
+// messages returns the messages delivered to the sink.
+func messages(t *testing.T, sink *Sink) []string {
+	t.Helper()
+	entries, err := sink.Entries()
+	if err != nil {
+		t.Fatal("Entries:", err)
+	}
+	msgs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		msgs = append(msgs, e.Msg)
+	}
+	return msgs
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
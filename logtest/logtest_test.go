@@ -0,0 +1,60 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package logtest
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"zombiezen.com/go/log"
+	"zombiezen.com/go/log/testlog"
+)
+
+func TestWriter(t *testing.T) {
+	TestLogger(t, func(t *testing.T) *Sink {
+		buf := new(bytes.Buffer)
+		return &Sink{
+			Logger:  log.New(buf, "", 0, nil),
+			Entries: func() ([]log.Entry, error) { return parseLines(buf.String()), nil },
+		}
+	})
+}
+
+func TestLevelFilter(t *testing.T) {
+	TestLogger(t, func(t *testing.T) *Sink {
+		buf := new(bytes.Buffer)
+		return &Sink{
+			Logger: &log.LevelFilter{
+				Min:    log.Warn,
+				Output: log.New(buf, "", 0, nil),
+			},
+			Entries: func() ([]log.Entry, error) { return parseLines(buf.String()), nil },
+		}
+	})
+}
+
+func TestRecorder(t *testing.T) {
+	TestLogger(t, func(t *testing.T) *Sink {
+		rec := new(testlog.Recorder)
+		return &Sink{
+			Logger:  rec,
+			Entries: func() ([]log.Entry, error) { return rec.Entries(), nil },
+		}
+	})
+}
+
+func parseLines(s string) []log.Entry {
+	var entries []log.Entry
+	for _, line := range strings.SplitAfter(s, "\n") {
+		if line == "" {
+			continue
+		}
+		entries = append(entries, log.Entry{Msg: strings.TrimSuffix(line, "\n")})
+	}
+	return entries
+}
@@ -0,0 +1,27 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package lokilog_test
+
+import (
+	"context"
+
+	"zombiezen.com/go/log"
+	"zombiezen.com/go/log/lokilog"
+)
+
+func ExampleNew() {
+	logger := lokilog.New("http://localhost:3100", &lokilog.Options{
+		Labels: map[string]string{"app": "myapp"},
+		Flags:  log.ShortFile,
+	})
+	log.SetDefault(logger)
+
+	// Send any buffered entries before the program exits.
+	defer logger.Close()
+
+	log.Infof(context.Background(), "Hello, World!")
+}
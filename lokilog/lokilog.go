@@ -0,0 +1,279 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package lokilog provides a Logger that sends entries to Grafana Loki's
+// push API.
+package lokilog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"zombiezen.com/go/log"
+	"zombiezen.com/go/log/internal/backoff"
+	"zombiezen.com/go/log/internal/batch"
+)
+
+// DefaultMaxAttempts is the number of times a batch is sent
+// if Options.MaxAttempts is zero.
+const DefaultMaxAttempts = 10
+
+// Options is the set of optional arguments to New.
+type Options struct {
+	// Client is used to make HTTP requests. If nil, a client with a
+	// 10 second timeout is used.
+	Client *http.Client
+	// Header holds additional HTTP headers to send with each request,
+	// like authorization or X-Scope-OrgID.
+	Header http.Header
+
+	// Labels is the set of labels attached to every stream. The "level" label
+	// is always set by the Logger to the entry's level.
+	Labels map[string]string
+	// Flags controls the formatting of each line as in log.Writer.
+	// Loki records the timestamp separately and the level as a label,
+	// so ShowDate, ShowTime, and ShowLevel are usually not wanted.
+	Flags log.Flags
+
+	// BatchSize is the maximum number of entries sent in a single request.
+	// If zero, 512 is used.
+	BatchSize int
+	// FlushInterval is the maximum amount of time an entry is buffered before
+	// it is sent. If zero, one second is used.
+	FlushInterval time.Duration
+	// MaxBuffer is the maximum number of entries buffered in memory.
+	// When the buffer is full, the oldest entries are dropped.
+	// If zero, 2048 is used.
+	MaxBuffer int
+	// MaxBufferBytes is the maximum total size of the lines buffered in memory.
+	// When exceeded, the oldest entries are dropped. If zero, there is no limit
+	// beyond MaxBuffer.
+	MaxBufferBytes int
+	// MaxAttempts is the number of times a request is attempted before its
+	// entries are dropped. If zero, DefaultMaxAttempts is used.
+	MaxAttempts int
+	// Backoff is the initial delay between attempts. Delays double for each
+	// attempt, up to MaxBackoff. If zero, 100 milliseconds is used.
+	Backoff time.Duration
+	// MaxBackoff is the maximum delay between attempts.
+	// If zero, 30 seconds is used.
+	MaxBackoff time.Duration
+
+	// ErrorFunc is called if not nil when entries are dropped.
+	// It must be safe to call from multiple goroutines and should be fast.
+	ErrorFunc func(context.Context, error)
+}
+
+// Logger is a log.Logger that sends entries in batches to Loki from a
+// background goroutine. Call Close to stop the goroutine and send any
+// buffered entries.
+type Logger struct {
+	url    string
+	client *http.Client
+	header http.Header
+	labels map[string]string
+	flags  log.Flags
+	q      *batch.Queue
+}
+
+// New returns a new Logger that pushes entries to the Loki server at baseURL,
+// like "http://localhost:3100". opts may be nil, in which case it is
+// treated the same as if new(Options) were passed.
+func New(baseURL string, opts *Options) *Logger {
+	if opts == nil {
+		opts = new(Options)
+	}
+	l := &Logger{
+		url:    trimSlash(baseURL) + "/loki/api/v1/push",
+		client: opts.Client,
+		header: opts.Header,
+		labels: opts.Labels,
+		flags:  opts.Flags,
+	}
+	if l.client == nil {
+		l.client = &http.Client{Timeout: 10 * time.Second}
+	}
+	maxAttempts := opts.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	l.q = batch.New(batch.Options{
+		Send:           l.send,
+		BatchSize:      opts.BatchSize,
+		Interval:       opts.FlushInterval,
+		MaxBuffer:      opts.MaxBuffer,
+		MaxBufferBytes: opts.MaxBufferBytes,
+		MaxAttempts:    maxAttempts,
+		Backoff: backoff.Policy{
+			Initial: opts.Backoff,
+			Max:     opts.MaxBackoff,
+		},
+		ErrorFunc: opts.ErrorFunc,
+	})
+	return l
+}
+
+func trimSlash(s string) string {
+	for len(s) > 0 && s[len(s)-1] == '/' {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+type record struct {
+	time  time.Time
+	level string
+	line  string
+}
+
+// Log buffers the entry to be sent. It does not wait for the entry to be
+// delivered. If the entry has no timestamp, the current time is used.
+func (l *Logger) Log(ctx context.Context, ent log.Entry) {
+	r := l.newRecord(ent)
+	l.q.Add(ctx, r, len(r.line))
+}
+
+func (l *Logger) newRecord(ent log.Entry) record {
+	r := record{
+		time:  ent.Time,
+		level: LevelLabel(ent.Level),
+		line:  string(ent.Append(nil, l.flags)),
+	}
+	if r.time.IsZero() {
+		r.time = time.Now()
+	}
+	return r
+}
+
+// LogEnabled always returns true.
+func (l *Logger) LogEnabled(log.Entry) bool { return true }
+
+// Send pushes entries in a single request, bypassing the Logger's buffer.
+// It makes one attempt and does not retry. Send allows the Logger to be used
+// with wrappers that do their own buffering, like
+// zombiezen.com/go/log/spoollog.
+func (l *Logger) Send(ctx context.Context, entries []log.Entry) error {
+	items := make([]interface{}, len(entries))
+	for i, ent := range entries {
+		items[i] = l.newRecord(ent)
+	}
+	return l.send(ctx, items)
+}
+
+// Flush sends any buffered entries and waits for them to be delivered or
+// dropped. Flush returns an error if any entries were dropped.
+func (l *Logger) Flush(ctx context.Context) error {
+	return l.q.Flush(ctx)
+}
+
+// Close sends any buffered entries and stops the Logger's background
+// goroutine. Entries logged after Close are dropped.
+func (l *Logger) Close() error {
+	return l.q.Close()
+}
+
+// LevelLabel returns the value of the "level" label for entries of the given
+// level. Levels between the predefined levels use the label of the next lower
+// predefined level.
+func LevelLabel(level log.Level) string {
+	switch {
+	case level < log.Info:
+		return "debug"
+	case level < log.Warn:
+		return "info"
+	case level < log.Error:
+		return "warn"
+	default:
+		return "error"
+	}
+}
+
+type pushRequest struct {
+	Streams []stream `json:"streams"`
+}
+
+type stream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+func (l *Logger) send(ctx context.Context, items []interface{}) error {
+	// Group records into one stream per level. Loki requires entries within
+	// a stream to be in timestamp order.
+	var levels []string
+	byLevel := make(map[string][]record)
+	for _, item := range items {
+		r := item.(record)
+		if _, ok := byLevel[r.level]; !ok {
+			levels = append(levels, r.level)
+		}
+		byLevel[r.level] = append(byLevel[r.level], r)
+	}
+	req := &pushRequest{Streams: make([]stream, 0, len(levels))}
+	for _, level := range levels {
+		records := byLevel[level]
+		sort.SliceStable(records, func(i, j int) bool {
+			return records[i].time.Before(records[j].time)
+		})
+		s := stream{
+			Stream: make(map[string]string, len(l.labels)+1),
+			Values: make([][2]string, 0, len(records)),
+		}
+		for k, v := range l.labels {
+			s.Stream[k] = v
+		}
+		s.Stream["level"] = level
+		for _, r := range records {
+			s.Values = append(s.Values, [2]string{strconv.FormatInt(r.time.UnixNano(), 10), r.line})
+		}
+		req.Streams = append(req.Streams, s)
+	}
+
+	body := new(bytes.Buffer)
+	zw := gzip.NewWriter(body)
+	if err := json.NewEncoder(zw).Encode(req); err != nil {
+		return batch.Permanent(fmt.Errorf("push to loki: %w", err))
+	}
+	if err := zw.Close(); err != nil {
+		return batch.Permanent(fmt.Errorf("push to loki: %w", err))
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, l.url, body)
+	if err != nil {
+		return batch.Permanent(fmt.Errorf("push to loki: %w", err))
+	}
+	for k, v := range l.header {
+		httpReq.Header[k] = v
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Content-Encoding", "gzip")
+	resp, err := l.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("push to loki: %w", err)
+	}
+	defer resp.Body.Close()
+	if 200 <= resp.StatusCode && resp.StatusCode < 300 {
+		io.Copy(ioutil.Discard, resp.Body)
+		return nil
+	}
+	msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	err = fmt.Errorf("push to loki: http %s: %s", resp.Status, bytes.TrimSpace(msg))
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+		return batch.Permanent(err)
+	}
+	if secs, perr := strconv.Atoi(resp.Header.Get("Retry-After")); perr == nil && secs > 0 {
+		return batch.RetryAfter(err, time.Duration(secs)*time.Second)
+	}
+	return err
+}
@@ -0,0 +1,211 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package lokilog
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"zombiezen.com/go/log"
+)
+
+var _ log.Logger = new(Logger)
+
+func TestLogger(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Payload", func(t *testing.T) {
+		srv := newFakeLoki()
+		defer srv.Close()
+		l := New(srv.URL+"/", &Options{
+			Labels:        map[string]string{"app": "myapp", "env": "test"},
+			Flags:         log.ShortFile,
+			FlushInterval: time.Hour,
+		})
+		base := time.Unix(1581452772, 0)
+		l.Log(ctx, log.Entry{Msg: "b", Level: log.Info, Time: base.Add(2), File: "/src/a.go", Line: 10})
+		l.Log(ctx, log.Entry{Msg: "err", Level: log.Error, Time: base, File: "/src/a.go", Line: 11})
+		l.Log(ctx, log.Entry{Msg: "a", Level: log.Info, Time: base.Add(1), File: "/src/a.go", Line: 12})
+		if err := l.Close(); err != nil {
+			t.Error("Close:", err)
+		}
+
+		reqs := srv.requests()
+		if len(reqs) != 1 {
+			t.Fatalf("server received %d requests; want 1", len(reqs))
+		}
+		want := pushRequest{Streams: []stream{
+			{
+				Stream: map[string]string{"app": "myapp", "env": "test", "level": "info"},
+				Values: [][2]string{
+					{"1581452772000000001", "a.go:12: a"},
+					{"1581452772000000002", "a.go:10: b"},
+				},
+			},
+			{
+				Stream: map[string]string{"app": "myapp", "env": "test", "level": "error"},
+				Values: [][2]string{
+					{"1581452772000000000", "a.go:11: err"},
+				},
+			},
+		}}
+		if diff := cmp.Diff(want, reqs[0]); diff != "" {
+			t.Errorf("push request (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		srv := newFakeLoki(http.StatusTooManyRequests, http.StatusInternalServerError)
+		defer srv.Close()
+		l := New(srv.URL, &Options{
+			FlushInterval: time.Hour,
+			Backoff:       time.Millisecond,
+		})
+		l.Log(ctx, log.Entry{Msg: "Hello, World!"})
+		if err := l.Flush(ctx); err != nil {
+			t.Error("Flush:", err)
+		}
+		l.Close()
+		if got := srv.attempts(); got != 3 {
+			t.Errorf("server received %d requests; want 3", got)
+		}
+	})
+
+	t.Run("BadRequest", func(t *testing.T) {
+		srv := newFakeLoki(http.StatusBadRequest)
+		defer srv.Close()
+		l := New(srv.URL, &Options{
+			FlushInterval: time.Hour,
+			Backoff:       time.Millisecond,
+		})
+		l.Log(ctx, log.Entry{Msg: "Hello, World!"})
+		if err := l.Flush(ctx); err == nil {
+			t.Error("Flush did not return an error")
+		}
+		l.Close()
+		if got := srv.attempts(); got != 1 {
+			t.Errorf("server received %d requests; want 1", got)
+		}
+	})
+
+	t.Run("Send", func(t *testing.T) {
+		srv := newFakeLoki(http.StatusServiceUnavailable)
+		defer srv.Close()
+		l := New(srv.URL, &Options{FlushInterval: time.Hour})
+		defer l.Close()
+		entries := []log.Entry{{Msg: "Hello, World!", Time: time.Unix(1581452772, 0)}}
+		if err := l.Send(ctx, entries); err == nil {
+			t.Error("Send did not return an error for 503 response")
+		}
+		if err := l.Send(ctx, entries); err != nil {
+			t.Error("Send:", err)
+		}
+		if got := srv.attempts(); got != 2 {
+			t.Errorf("server received %d requests; want 2", got)
+		}
+	})
+
+	t.Run("MaxBufferBytes", func(t *testing.T) {
+		srv := newFakeLoki()
+		defer srv.Close()
+		dropped := 0
+		l := New(srv.URL, &Options{
+			FlushInterval:  time.Hour,
+			MaxBufferBytes: 10,
+			ErrorFunc: func(context.Context, error) {
+				dropped++
+			},
+		})
+		l.Log(ctx, log.Entry{Msg: "123456"})
+		l.Log(ctx, log.Entry{Msg: "abcdef"})
+		if dropped != 1 {
+			t.Errorf("ErrorFunc called %d times; want 1", dropped)
+		}
+		l.Close()
+		reqs := srv.requests()
+		if len(reqs) != 1 || len(reqs[0].Streams) != 1 || len(reqs[0].Streams[0].Values) != 1 {
+			t.Fatalf("requests = %+v; want single entry", reqs)
+		}
+		if got, want := reqs[0].Streams[0].Values[0][1], "abcdef"; got != want {
+			t.Errorf("line = %q; want %q", got, want)
+		}
+	})
+}
+
+type fakeLoki struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	statuses []int
+	calls    int
+	reqs     []pushRequest
+}
+
+// newFakeLoki returns a server that responds with the given statuses
+// in order, then 204 No Content for any further requests.
+func newFakeLoki(statuses ...int) *fakeLoki {
+	f := &fakeLoki{statuses: statuses}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	return f
+}
+
+func (f *fakeLoki) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.calls++
+	status := http.StatusNoContent
+	if len(f.statuses) > 0 {
+		status = f.statuses[0]
+		f.statuses = f.statuses[1:]
+	}
+	f.mu.Unlock()
+	if status != http.StatusNoContent {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	if r.URL.Path != "/loki/api/v1/push" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Header.Get("Content-Encoding") != "gzip" {
+		http.Error(w, "not gzipped", http.StatusBadRequest)
+		return
+	}
+	zr, err := gzip.NewReader(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var req pushRequest
+	if err := json.NewDecoder(zr).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f.mu.Lock()
+	f.reqs = append(f.reqs, req)
+	f.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeLoki) attempts() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func (f *fakeLoki) requests() []pushRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]pushRequest(nil), f.reqs...)
+}
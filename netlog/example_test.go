@@ -0,0 +1,30 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package netlog_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"zombiezen.com/go/log"
+	"zombiezen.com/go/log/netlog"
+)
+
+func ExampleNew() {
+	logger := netlog.New("localhost:5170", &netlog.Options{
+		ErrorFunc: func(ctx context.Context, err error) {
+			fmt.Fprintln(os.Stderr, "log:", err)
+		},
+	})
+	log.SetDefault(logger)
+
+	// Write any buffered entries before the program exits.
+	defer logger.Close()
+
+	log.Infof(context.Background(), "Hello, World!")
+}
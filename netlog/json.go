@@ -0,0 +1,104 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package netlog
+
+import (
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"zombiezen.com/go/log"
+)
+
+// appendJSON appends the entry to a buffer as a single-line JSON object
+// with the keys "time", "level", "msg", and if the entry has a file name,
+// "file" and "line". The time is formatted in RFC 3339 with nanoseconds and
+// omitted if zero. A trailing newline in ent.Msg will be trimmed.
+// No newline is appended after the object.
+func appendJSON(buf []byte, ent log.Entry) []byte {
+	buf = append(buf, '{')
+	if !ent.Time.IsZero() {
+		buf = append(buf, `"time":"`...)
+		buf = ent.Time.AppendFormat(buf, time.RFC3339Nano)
+		buf = append(buf, `",`...)
+	}
+	buf = append(buf, `"level":`...)
+	buf = appendJSONString(buf, levelName(ent.Level))
+	buf = append(buf, `,"msg":`...)
+	s := ent.Msg
+	if n := len(s); n > 0 && s[n-1] == '\n' {
+		s = s[:n-1]
+	}
+	buf = appendJSONString(buf, s)
+	if ent.File != "" {
+		buf = append(buf, `,"file":`...)
+		buf = appendJSONString(buf, ent.File)
+		buf = append(buf, `,"line":`...)
+		buf = strconv.AppendInt(buf, int64(ent.Line), 10)
+	}
+	buf = append(buf, '}')
+	return buf
+}
+
+// levelName returns the name of a predefined level in all caps
+// or the result of l.String() for other levels.
+func levelName(l log.Level) string {
+	switch l {
+	case log.Debug:
+		return "DEBUG"
+	case log.Info:
+		return "INFO"
+	case log.Warn:
+		return "WARN"
+	case log.Error:
+		return "ERROR"
+	default:
+		return l.String()
+	}
+}
+
+func appendJSONString(buf []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+	buf = append(buf, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' {
+				i++
+				continue
+			}
+			buf = append(buf, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				buf = append(buf, '\\', c)
+			case '\n':
+				buf = append(buf, '\\', 'n')
+			case '\r':
+				buf = append(buf, '\\', 'r')
+			case '\t':
+				buf = append(buf, '\\', 't')
+			default:
+				buf = append(buf, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, "\ufffd"...)
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	buf = append(buf, s[start:]...)
+	buf = append(buf, '"')
+	return buf
+}
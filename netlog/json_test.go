@@ -0,0 +1,61 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package netlog
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"zombiezen.com/go/log"
+)
+
+func TestAppendJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry log.Entry
+		want  string
+	}{
+		{
+			name: "basic",
+			entry: log.Entry{
+				Msg:   "Hello, World!\n",
+				Level: log.Warn,
+				Time:  time.Date(2017, time.February, 17, 1, 2, 3, 456789000, time.UTC),
+				File:  "foo/bar.go",
+				Line:  278,
+			},
+			want: `{"time":"2017-02-17T01:02:03.456789Z","level":"WARN","msg":"Hello, World!","file":"foo/bar.go","line":278}`,
+		},
+		{
+			name:  "no time or file",
+			entry: log.Entry{Msg: "Hello", Level: log.Info},
+			want:  `{"level":"INFO","msg":"Hello"}`,
+		},
+		{
+			name:  "custom level",
+			entry: log.Entry{Msg: "Hello", Level: 5},
+			want:  `{"level":"Level(5)","msg":"Hello"}`,
+		},
+		{
+			name:  "escapes",
+			entry: log.Entry{Msg: "a\"b\\c\nd\te\x01f\xffgé", Level: log.Debug},
+			want:  `{"level":"DEBUG","msg":"a\"b\\c\nd\te\u0001f` + "\ufffdgé" + `"}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := appendJSON(nil, test.entry)
+			if string(got) != test.want {
+				t.Errorf("appendJSON(nil, entry) = %s; want %s", got, test.want)
+			}
+			if !json.Valid(got) {
+				t.Errorf("appendJSON(nil, entry) = %s; not valid JSON", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package netlog provides a Logger that writes newline-delimited entries to a
+// TCP or Unix stream socket, as expected by the TCP inputs of log shippers
+// like Logstash or Vector.
+package netlog
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"math"
+	"net"
+	"time"
+
+	"zombiezen.com/go/log"
+	"zombiezen.com/go/log/internal/backoff"
+	"zombiezen.com/go/log/internal/batch"
+)
+
+// DefaultMaxBufferBytes is the buffer size used if Options.MaxBufferBytes is zero.
+const DefaultMaxBufferBytes = 1 << 20
+
+// Options is the set of optional arguments to New.
+type Options struct {
+	// Network is the network passed to net.Dial, like "tcp" or "unix".
+	// If empty, "tcp" is used.
+	Network string
+	// TLSConfig is used to establish a TLS connection if not nil.
+	// Set TLSConfig.RootCAs to trust a private certificate authority.
+	TLSConfig *tls.Config
+
+	// Encode appends the encoding of an entry to dst. The encoding must not
+	// contain a newline, as one is added after each entry.
+	// If nil, each entry is encoded as a JSON object with the keys "time",
+	// "level", "msg", and if the entry has a file name, "file" and "line".
+	Encode func(dst []byte, ent log.Entry) []byte
+
+	// DialTimeout is the timeout for establishing a connection.
+	// If zero, 10 seconds is used.
+	DialTimeout time.Duration
+	// WriteTimeout is the timeout for writing a batch of entries.
+	// If zero, 10 seconds is used.
+	WriteTimeout time.Duration
+
+	// FlushInterval is the maximum amount of time an entry is buffered before
+	// it is written. If zero, one second is used.
+	FlushInterval time.Duration
+	// MaxBufferBytes is the maximum total size of encoded entries buffered in
+	// memory, such as while the connection is down. When exceeded, the oldest
+	// entries are dropped. If zero, DefaultMaxBufferBytes is used.
+	MaxBufferBytes int
+	// Backoff is the initial delay between reconnection attempts. Delays double
+	// for each attempt, up to MaxBackoff. If zero, 100 milliseconds is used.
+	Backoff time.Duration
+	// MaxBackoff is the maximum delay between reconnection attempts.
+	// If zero, 30 seconds is used.
+	MaxBackoff time.Duration
+
+	// ErrorFunc is called if not nil when connecting or writing fails and when
+	// entries are dropped. It must be safe to call from multiple goroutines and
+	// should be fast.
+	ErrorFunc func(context.Context, error)
+}
+
+// Logger is a log.Logger that writes entries to a stream socket from a
+// background goroutine. The connection is established lazily and
+// reestablished after any error. Call Close to stop the goroutine and write
+// any buffered entries.
+type Logger struct {
+	network      string
+	addr         string
+	tlsConfig    *tls.Config
+	encode       func([]byte, log.Entry) []byte
+	dialTimeout  time.Duration
+	writeTimeout time.Duration
+	errFunc      func(context.Context, error)
+	q            *batch.Queue
+
+	// conn is only accessed by the queue's goroutine and,
+	// after the queue is closed, by Close.
+	conn net.Conn
+}
+
+// New returns a new Logger that writes entries to the given address,
+// like "localhost:5170" or "/var/run/vector.sock". opts may be nil,
+// in which case it is treated the same as if new(Options) were passed.
+func New(addr string, opts *Options) *Logger {
+	if opts == nil {
+		opts = new(Options)
+	}
+	l := &Logger{
+		network:      opts.Network,
+		addr:         addr,
+		tlsConfig:    opts.TLSConfig,
+		encode:       opts.Encode,
+		dialTimeout:  opts.DialTimeout,
+		writeTimeout: opts.WriteTimeout,
+		errFunc:      opts.ErrorFunc,
+	}
+	if l.network == "" {
+		l.network = "tcp"
+	}
+	if l.encode == nil {
+		l.encode = appendJSON
+	}
+	if l.dialTimeout <= 0 {
+		l.dialTimeout = 10 * time.Second
+	}
+	if l.writeTimeout <= 0 {
+		l.writeTimeout = 10 * time.Second
+	}
+	maxBytes := opts.MaxBufferBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBufferBytes
+	}
+	l.q = batch.New(batch.Options{
+		Send:           l.send,
+		Interval:       opts.FlushInterval,
+		MaxBuffer:      math.MaxInt32,
+		MaxBufferBytes: maxBytes,
+		Backoff: backoff.Policy{
+			Initial: opts.Backoff,
+			Max:     opts.MaxBackoff,
+		},
+		ErrorFunc: opts.ErrorFunc,
+	})
+	return l
+}
+
+// Log buffers the entry to be written. It does not wait for the entry to be
+// delivered.
+func (l *Logger) Log(ctx context.Context, ent log.Entry) {
+	line := l.encode(nil, ent)
+	line = append(line, '\n')
+	l.q.Add(ctx, line, len(line))
+}
+
+// LogEnabled always returns true.
+func (l *Logger) LogEnabled(log.Entry) bool { return true }
+
+// Flush writes any buffered entries and waits for them to be written or
+// dropped. Flush returns an error if any entries were dropped.
+func (l *Logger) Flush(ctx context.Context) error {
+	return l.q.Flush(ctx)
+}
+
+// Close writes any buffered entries, stops the Logger's background goroutine,
+// and closes the connection. Entries logged after Close are dropped.
+func (l *Logger) Close() error {
+	err := l.q.Close()
+	if l.conn != nil {
+		l.conn.Close()
+		l.conn = nil
+	}
+	return err
+}
+
+func (l *Logger) send(ctx context.Context, items []interface{}) error {
+	var buf []byte
+	for _, item := range items {
+		buf = append(buf, item.([]byte)...)
+	}
+	if l.conn == nil {
+		conn, err := l.dial(ctx)
+		if err != nil {
+			return l.fail(fmt.Errorf("connect to %s: %w", l.addr, err))
+		}
+		l.conn = conn
+	}
+	if err := l.conn.SetWriteDeadline(time.Now().Add(l.writeTimeout)); err != nil {
+		return l.fail(fmt.Errorf("write to %s: %w", l.addr, err))
+	}
+	if _, err := l.conn.Write(buf); err != nil {
+		return l.fail(fmt.Errorf("write to %s: %w", l.addr, err))
+	}
+	return nil
+}
+
+func (l *Logger) dial(ctx context.Context) (net.Conn, error) {
+	d := &net.Dialer{Timeout: l.dialTimeout}
+	conn, err := d.DialContext(ctx, l.network, l.addr)
+	if err != nil {
+		return nil, err
+	}
+	if l.tlsConfig == nil {
+		return conn, nil
+	}
+	cfg := l.tlsConfig
+	if cfg.ServerName == "" {
+		cfg = cfg.Clone()
+		if host, _, err := net.SplitHostPort(l.addr); err == nil {
+			cfg.ServerName = host
+		} else {
+			cfg.ServerName = l.addr
+		}
+	}
+	tlsConn := tls.Client(conn, cfg)
+	tlsConn.SetDeadline(time.Now().Add(l.dialTimeout))
+	if err := tlsConn.Handshake(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	tlsConn.SetDeadline(time.Time{})
+	return tlsConn, nil
+}
+
+// fail closes the connection and reports err to the ErrorFunc.
+func (l *Logger) fail(err error) error {
+	if l.conn != nil {
+		l.conn.Close()
+		l.conn = nil
+	}
+	if l.errFunc != nil {
+		l.errFunc(context.Background(), err)
+	}
+	return err
+}
@@ -0,0 +1,212 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package netlog
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"zombiezen.com/go/log"
+)
+
+var _ log.Logger = new(Logger)
+
+func TestLogger(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("TCP", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		srv := serveLines(ln)
+		defer srv.close()
+		l := New(ln.Addr().String(), &Options{FlushInterval: time.Hour})
+		l.Log(ctx, log.Entry{Msg: "Hello, World!", Level: log.Info})
+		l.Log(ctx, log.Entry{Msg: "second", Level: log.Warn})
+		if err := l.Close(); err != nil {
+			t.Error("Close:", err)
+		}
+		want := []string{
+			`{"level":"INFO","msg":"Hello, World!"}`,
+			`{"level":"WARN","msg":"second"}`,
+		}
+		if diff := cmp.Diff(want, srv.wait(t, 2)); diff != "" {
+			t.Errorf("lines (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Reconnect", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "netlog")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "log.sock")
+		connErrs := make(chan error, 100)
+		l := New(path, &Options{
+			Network:       "unix",
+			FlushInterval: time.Millisecond,
+			Backoff:       time.Millisecond,
+			MaxBackoff:    10 * time.Millisecond,
+			Encode: func(dst []byte, ent log.Entry) []byte {
+				return append(dst, ent.Msg...)
+			},
+			ErrorFunc: func(_ context.Context, err error) {
+				select {
+				case connErrs <- err:
+				default:
+				}
+			},
+		})
+		defer l.Close()
+		l.Log(ctx, log.Entry{Msg: "first"})
+		select {
+		case <-connErrs:
+		case <-time.After(10 * time.Second):
+			t.Fatal("ErrorFunc not called while socket not listening")
+		}
+
+		ln, err := net.Listen("unix", path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		srv := serveLines(ln)
+		defer srv.close()
+		l.Log(ctx, log.Entry{Msg: "second"})
+		if err := l.Flush(ctx); err != nil {
+			t.Error("Flush:", err)
+		}
+		if diff := cmp.Diff([]string{"first", "second"}, srv.wait(t, 2)); diff != "" {
+			t.Errorf("lines (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("MaxBufferBytes", func(t *testing.T) {
+		dropped := 0
+		l := New("127.0.0.1:1", &Options{
+			FlushInterval:  time.Hour,
+			MaxBufferBytes: 10,
+			Encode: func(dst []byte, ent log.Entry) []byte {
+				return append(dst, ent.Msg...)
+			},
+			ErrorFunc: func(context.Context, error) {
+				dropped++
+			},
+		})
+		l.Log(ctx, log.Entry{Msg: "1234"})
+		l.Log(ctx, log.Entry{Msg: "5678"})
+		if dropped != 0 {
+			t.Errorf("dropped entry while under limit")
+		}
+		l.Log(ctx, log.Entry{Msg: "9abc"})
+		if dropped != 1 {
+			t.Errorf("ErrorFunc called %d times; want 1", dropped)
+		}
+		l.Close()
+	})
+
+	t.Run("TLS", func(t *testing.T) {
+		// Borrow the test certificate from httptest.
+		httpSrv := httptest.NewTLSServer(nil)
+		defer httpSrv.Close()
+		roots := x509.NewCertPool()
+		roots.AddCert(httpSrv.Certificate())
+		ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+			Certificates: httpSrv.TLS.Certificates,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		srv := serveLines(ln)
+		defer srv.close()
+		l := New(ln.Addr().String(), &Options{
+			TLSConfig:     &tls.Config{RootCAs: roots},
+			FlushInterval: time.Hour,
+		})
+		l.Log(ctx, log.Entry{Msg: "secret", Level: log.Error})
+		if err := l.Close(); err != nil {
+			t.Error("Close:", err)
+		}
+		want := []string{`{"level":"ERROR","msg":"secret"}`}
+		if diff := cmp.Diff(want, srv.wait(t, 1)); diff != "" {
+			t.Errorf("lines (-want +got):\n%s", diff)
+		}
+	})
+}
+
+type lineServer struct {
+	ln    net.Listener
+	lines chan string
+	wg    sync.WaitGroup
+
+	mu    sync.Mutex
+	conns []net.Conn
+}
+
+func serveLines(ln net.Listener) *lineServer {
+	srv := &lineServer{ln: ln, lines: make(chan string, 100)}
+	srv.wg.Add(1)
+	go func() {
+		defer srv.wg.Done()
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			srv.mu.Lock()
+			srv.conns = append(srv.conns, conn)
+			srv.mu.Unlock()
+			srv.wg.Add(1)
+			go func() {
+				defer srv.wg.Done()
+				defer conn.Close()
+				s := bufio.NewScanner(conn)
+				for s.Scan() {
+					srv.lines <- s.Text()
+				}
+			}()
+		}
+	}()
+	return srv
+}
+
+func (srv *lineServer) wait(t *testing.T, n int) []string {
+	t.Helper()
+	var lines []string
+	timeout := time.After(10 * time.Second)
+	for len(lines) < n {
+		select {
+		case line := <-srv.lines:
+			lines = append(lines, line)
+		case <-timeout:
+			t.Fatalf("received %q; want %d lines", lines, n)
+		}
+	}
+	return lines
+}
+
+func (srv *lineServer) close() {
+	srv.ln.Close()
+	srv.mu.Lock()
+	for _, conn := range srv.conns {
+		conn.Close()
+	}
+	srv.mu.Unlock()
+	srv.wg.Wait()
+}
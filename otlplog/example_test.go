@@ -0,0 +1,26 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package otlplog_test
+
+import (
+	"context"
+
+	"zombiezen.com/go/log"
+	"zombiezen.com/go/log/otlplog"
+)
+
+func ExampleNew() {
+	logger := otlplog.New("http://localhost:4318/v1/logs", &otlplog.Options{
+		ServiceName: "myservice",
+	})
+	log.SetDefault(logger)
+
+	// Send any buffered entries before the program exits.
+	defer logger.Close()
+
+	log.Infof(context.Background(), "Hello, World!")
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package otlplog
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// scopeName is the instrumentation scope reported for all entries.
+const scopeName = "zombiezen.com/go/log"
+
+// The following types are the subset of the OTLP protobuf messages needed to
+// send logs, in the JSON mapping described by
+// https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding.
+// 64-bit integers are encoded as strings.
+
+type exportRequest struct {
+	ResourceLogs []resourceLogs `json:"resourceLogs"`
+}
+
+type resourceLogs struct {
+	Resource  resource    `json:"resource"`
+	ScopeLogs []scopeLogs `json:"scopeLogs"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes,omitempty"`
+}
+
+type scopeLogs struct {
+	Scope      scope       `json:"scope"`
+	LogRecords []logRecord `json:"logRecords"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type logRecord struct {
+	TimeUnixNano         string     `json:"timeUnixNano,omitempty"`
+	ObservedTimeUnixNano string     `json:"observedTimeUnixNano,omitempty"`
+	SeverityNumber       int        `json:"severityNumber"`
+	SeverityText         string     `json:"severityText,omitempty"`
+	Body                 anyValue   `json:"body"`
+	Attributes           []keyValue `json:"attributes,omitempty"`
+	Flags                uint32     `json:"flags,omitempty"`
+	TraceID              string     `json:"traceId,omitempty"`
+	SpanID               string     `json:"spanId,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    string  `json:"intValue,omitempty"`
+}
+
+func stringAttr(key, value string) keyValue {
+	return keyValue{Key: key, Value: anyValue{StringValue: &value}}
+}
+
+func intAttr(key string, value int64) keyValue {
+	return keyValue{Key: key, Value: anyValue{IntValue: strconv.FormatInt(value, 10)}}
+}
+
+func newLogRecord(r record) logRecord {
+	msg := strings.TrimSuffix(r.ent.Msg, "\n")
+	lr := logRecord{
+		ObservedTimeUnixNano: unixNano(r.observed),
+		TimeUnixNano:         unixNano(r.ent.Time),
+		SeverityNumber:       SeverityNumber(r.ent.Level),
+		SeverityText:         severityText(r.ent.Level),
+		Body:                 anyValue{StringValue: &msg},
+	}
+	if r.ent.File != "" {
+		lr.Attributes = append(lr.Attributes, stringAttr("code.filepath", r.ent.File))
+		if r.ent.Line > 0 {
+			lr.Attributes = append(lr.Attributes, intAttr("code.lineno", int64(r.ent.Line)))
+		}
+	}
+	if r.span.IsValid() {
+		lr.TraceID = hex.EncodeToString(r.span.TraceID[:])
+		lr.SpanID = hex.EncodeToString(r.span.SpanID[:])
+		lr.Flags = uint32(r.span.TraceFlags)
+	}
+	return lr
+}
+
+func unixNano(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
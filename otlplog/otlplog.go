@@ -0,0 +1,252 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package otlplog provides a Logger that exports entries to an OpenTelemetry
+// collector using the OTLP/HTTP protocol with JSON encoding.
+package otlplog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"zombiezen.com/go/log"
+	"zombiezen.com/go/log/internal/backoff"
+	"zombiezen.com/go/log/internal/batch"
+)
+
+// DefaultMaxAttempts is the number of times a batch is sent
+// if Options.MaxAttempts is zero.
+const DefaultMaxAttempts = 5
+
+// Options is the set of optional arguments to New.
+type Options struct {
+	// Client is used to make HTTP requests. If nil, a client with a
+	// 10 second timeout is used.
+	Client *http.Client
+	// Header holds additional HTTP headers to send with each request,
+	// like authorization.
+	Header http.Header
+
+	// ServiceName is sent as the "service.name" resource attribute if not empty.
+	ServiceName string
+	// ResourceAttributes is a set of additional resource attributes
+	// sent with every batch.
+	ResourceAttributes map[string]string
+
+	// SpanContext is called in Log to obtain the trace context of an entry.
+	// If nil, SpanContextFromContext is used.
+	SpanContext func(context.Context) SpanContext
+
+	// BatchSize is the maximum number of entries sent in a single request.
+	// If zero, 512 is used.
+	BatchSize int
+	// FlushInterval is the maximum amount of time an entry is buffered before
+	// it is sent. If zero, one second is used.
+	FlushInterval time.Duration
+	// MaxBuffer is the maximum number of entries buffered in memory.
+	// When the buffer is full, the oldest entries are dropped.
+	// If zero, 2048 is used.
+	MaxBuffer int
+	// MaxAttempts is the number of times a request is attempted before its
+	// entries are dropped. If zero, DefaultMaxAttempts is used.
+	MaxAttempts int
+	// Backoff is the initial delay between attempts. Delays double for each
+	// attempt, up to MaxBackoff. If zero, 100 milliseconds is used.
+	Backoff time.Duration
+	// MaxBackoff is the maximum delay between attempts.
+	// If zero, 30 seconds is used.
+	MaxBackoff time.Duration
+
+	// ErrorFunc is called if not nil when entries are dropped.
+	// It must be safe to call from multiple goroutines and should be fast.
+	ErrorFunc func(context.Context, error)
+}
+
+// Logger is a log.Logger that sends entries in batches to an OTLP/HTTP
+// endpoint from a background goroutine. Call Close to stop the goroutine
+// and send any buffered entries.
+type Logger struct {
+	endpoint    string
+	client      *http.Client
+	header      http.Header
+	resource    resource
+	spanContext func(context.Context) SpanContext
+	q           *batch.Queue
+}
+
+// New returns a new Logger that sends entries to the given endpoint,
+// like "http://localhost:4318/v1/logs". opts may be nil, in which case it is
+// treated the same as if new(Options) were passed.
+func New(endpoint string, opts *Options) *Logger {
+	if opts == nil {
+		opts = new(Options)
+	}
+	l := &Logger{
+		endpoint:    endpoint,
+		client:      opts.Client,
+		header:      opts.Header,
+		spanContext: opts.SpanContext,
+	}
+	if l.client == nil {
+		l.client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if l.spanContext == nil {
+		l.spanContext = SpanContextFromContext
+	}
+	if opts.ServiceName != "" {
+		l.resource.Attributes = append(l.resource.Attributes, stringAttr("service.name", opts.ServiceName))
+	}
+	for k, v := range opts.ResourceAttributes {
+		l.resource.Attributes = append(l.resource.Attributes, stringAttr(k, v))
+	}
+	maxAttempts := opts.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	l.q = batch.New(batch.Options{
+		Send:        l.send,
+		BatchSize:   opts.BatchSize,
+		Interval:    opts.FlushInterval,
+		MaxBuffer:   opts.MaxBuffer,
+		MaxAttempts: maxAttempts,
+		Backoff: backoff.Policy{
+			Initial: opts.Backoff,
+			Max:     opts.MaxBackoff,
+		},
+		ErrorFunc: opts.ErrorFunc,
+	})
+	return l
+}
+
+type record struct {
+	ent      log.Entry
+	observed time.Time
+	span     SpanContext
+}
+
+// Log buffers the entry to be sent. It does not wait for the entry to be
+// delivered. The trace context is obtained from ctx.
+func (l *Logger) Log(ctx context.Context, ent log.Entry) {
+	l.q.Add(ctx, record{
+		ent:      ent,
+		observed: time.Now(),
+		span:     l.spanContext(ctx),
+	}, 0)
+}
+
+// LogEnabled always returns true.
+func (l *Logger) LogEnabled(log.Entry) bool { return true }
+
+// Send exports entries in a single request, bypassing the Logger's buffer.
+// It makes one attempt and does not retry. The trace context for all of the
+// entries is obtained from ctx. Send allows the Logger to be used with
+// wrappers that do their own buffering, like zombiezen.com/go/log/spoollog.
+func (l *Logger) Send(ctx context.Context, entries []log.Entry) error {
+	now := time.Now()
+	span := l.spanContext(ctx)
+	items := make([]interface{}, len(entries))
+	for i, ent := range entries {
+		items[i] = record{ent: ent, observed: now, span: span}
+	}
+	return l.send(ctx, items)
+}
+
+// Flush sends any buffered entries and waits for them to be delivered or
+// dropped. Flush returns an error if any entries were dropped.
+func (l *Logger) Flush(ctx context.Context) error {
+	return l.q.Flush(ctx)
+}
+
+// Close sends any buffered entries and stops the Logger's background
+// goroutine. Entries logged after Close are dropped.
+func (l *Logger) Close() error {
+	return l.q.Close()
+}
+
+func (l *Logger) send(ctx context.Context, items []interface{}) error {
+	records := make([]logRecord, 0, len(items))
+	for _, item := range items {
+		records = append(records, newLogRecord(item.(record)))
+	}
+	body, err := json.Marshal(&exportRequest{
+		ResourceLogs: []resourceLogs{{
+			Resource: l.resource,
+			ScopeLogs: []scopeLogs{{
+				Scope:      scope{Name: scopeName},
+				LogRecords: records,
+			}},
+		}},
+	})
+	if err != nil {
+		return batch.Permanent(fmt.Errorf("export logs: %w", err))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return batch.Permanent(fmt.Errorf("export logs: %w", err))
+	}
+	for k, v := range l.header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("export logs: %w", err)
+	}
+	defer resp.Body.Close()
+	if 200 <= resp.StatusCode && resp.StatusCode < 300 {
+		io.Copy(ioutil.Discard, resp.Body)
+		return nil
+	}
+	msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	err = fmt.Errorf("export logs: http %s: %s", resp.Status, bytes.TrimSpace(msg))
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if secs, perr := strconv.Atoi(resp.Header.Get("Retry-After")); perr == nil && secs > 0 {
+			return batch.RetryAfter(err, time.Duration(secs)*time.Second)
+		}
+		return err
+	default:
+		return batch.Permanent(err)
+	}
+}
+
+// SeverityNumber returns the OpenTelemetry severity number for a level.
+// Levels between the predefined levels map to the severity of the next lower
+// predefined level.
+func SeverityNumber(level log.Level) int {
+	switch {
+	case level < log.Info:
+		return 5 // DEBUG
+	case level < log.Warn:
+		return 9 // INFO
+	case level < log.Error:
+		return 13 // WARN
+	default:
+		return 17 // ERROR
+	}
+}
+
+func severityText(level log.Level) string {
+	switch level {
+	case log.Debug:
+		return "DEBUG"
+	case log.Info:
+		return "INFO"
+	case log.Warn:
+		return "WARN"
+	case log.Error:
+		return "ERROR"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package otlplog
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"zombiezen.com/go/log"
+)
+
+var _ log.Logger = new(Logger)
+
+func TestLogger(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Payload", func(t *testing.T) {
+		srv := newFakeCollector()
+		defer srv.Close()
+		l := New(srv.URL+"/v1/logs", &Options{
+			Header:        http.Header{"Authorization": {"Bearer xyzzy"}},
+			ServiceName:   "myservice",
+			FlushInterval: time.Hour,
+		})
+		span := SpanContext{
+			TraceID:    [16]byte{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+			SpanID:     [8]byte{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+			TraceFlags: 1,
+		}
+		l.Log(ContextWithSpanContext(ctx, span), log.Entry{
+			Msg:   "Hello, World!\n",
+			Time:  time.Unix(1581452772, 123),
+			Level: log.Warn,
+			File:  "/src/foo/bar.go",
+			Line:  42,
+		})
+		l.Log(ctx, log.Entry{
+			Msg:   "second",
+			Level: log.Debug,
+		})
+		if err := l.Close(); err != nil {
+			t.Error("Close:", err)
+		}
+
+		reqs := srv.requests()
+		if len(reqs) != 1 {
+			t.Fatalf("collector received %d requests; want 1", len(reqs))
+		}
+		if got, want := reqs[0].header.Get("Authorization"), "Bearer xyzzy"; got != want {
+			t.Errorf("Authorization = %q; want %q", got, want)
+		}
+		if got, want := reqs[0].header.Get("Content-Type"), "application/json"; got != want {
+			t.Errorf("Content-Type = %q; want %q", got, want)
+		}
+		rl := reqs[0].body["resourceLogs"].([]interface{})[0].(map[string]interface{})
+		wantResource := map[string]interface{}{
+			"attributes": []interface{}{
+				map[string]interface{}{
+					"key":   "service.name",
+					"value": map[string]interface{}{"stringValue": "myservice"},
+				},
+			},
+		}
+		if diff := cmp.Diff(wantResource, rl["resource"]); diff != "" {
+			t.Errorf("resource (-want +got):\n%s", diff)
+		}
+		sl := rl["scopeLogs"].([]interface{})[0].(map[string]interface{})
+		records := sl["logRecords"].([]interface{})
+		for _, r := range records {
+			delete(r.(map[string]interface{}), "observedTimeUnixNano")
+		}
+		want := []interface{}{
+			map[string]interface{}{
+				"timeUnixNano":   "1581452772000000123",
+				"severityNumber": 13.0,
+				"severityText":   "WARN",
+				"body":           map[string]interface{}{"stringValue": "Hello, World!"},
+				"attributes": []interface{}{
+					map[string]interface{}{
+						"key":   "code.filepath",
+						"value": map[string]interface{}{"stringValue": "/src/foo/bar.go"},
+					},
+					map[string]interface{}{
+						"key":   "code.lineno",
+						"value": map[string]interface{}{"intValue": "42"},
+					},
+				},
+				"traceId": "4bf92f3577b34da6a3ce929d0e0e4736",
+				"spanId":  "00f067aa0ba902b7",
+				"flags":   1.0,
+			},
+			map[string]interface{}{
+				"severityNumber": 5.0,
+				"severityText":   "DEBUG",
+				"body":           map[string]interface{}{"stringValue": "second"},
+			},
+		}
+		if diff := cmp.Diff(want, records); diff != "" {
+			t.Errorf("logRecords (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		srv := newFakeCollector(http.StatusServiceUnavailable, http.StatusTooManyRequests)
+		defer srv.Close()
+		l := New(srv.URL, &Options{
+			FlushInterval: time.Hour,
+			Backoff:       time.Millisecond,
+		})
+		l.Log(ctx, log.Entry{Msg: "Hello, World!"})
+		if err := l.Flush(ctx); err != nil {
+			t.Error("Flush:", err)
+		}
+		l.Close()
+		if got := len(srv.requests()); got != 3 {
+			t.Errorf("collector received %d requests; want 3", got)
+		}
+	})
+
+	t.Run("BadRequest", func(t *testing.T) {
+		srv := newFakeCollector(http.StatusBadRequest, http.StatusBadRequest)
+		defer srv.Close()
+		errCount := 0
+		var mu sync.Mutex
+		l := New(srv.URL, &Options{
+			FlushInterval: time.Hour,
+			Backoff:       time.Millisecond,
+			ErrorFunc: func(context.Context, error) {
+				mu.Lock()
+				errCount++
+				mu.Unlock()
+			},
+		})
+		l.Log(ctx, log.Entry{Msg: "Hello, World!"})
+		if err := l.Flush(ctx); err == nil {
+			t.Error("Flush did not return an error")
+		}
+		l.Close()
+		if got := len(srv.requests()); got != 1 {
+			t.Errorf("collector received %d requests; want 1", got)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if errCount != 1 {
+			t.Errorf("ErrorFunc called %d times; want 1", errCount)
+		}
+	})
+}
+
+func TestSeverityNumber(t *testing.T) {
+	tests := []struct {
+		level log.Level
+		want  int
+	}{
+		{log.Debug, 5},
+		{log.Debug - 1, 5},
+		{log.Info - 1, 5},
+		{log.Info, 9},
+		{log.Warn - 1, 9},
+		{log.Warn, 13},
+		{log.Error, 17},
+		{log.Error + 100, 17},
+	}
+	for _, test := range tests {
+		if got := SeverityNumber(test.level); got != test.want {
+			t.Errorf("SeverityNumber(%v) = %d; want %d", test.level, got, test.want)
+		}
+	}
+}
+
+type fakeCollector struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	statuses []int
+	reqs     []collectorRequest
+}
+
+type collectorRequest struct {
+	header http.Header
+	body   map[string]interface{}
+}
+
+// newFakeCollector returns a server that responds with the given statuses
+// in order, then 200 OK for any further requests.
+func newFakeCollector(statuses ...int) *fakeCollector {
+	c := &fakeCollector{statuses: statuses}
+	c.Server = httptest.NewServer(http.HandlerFunc(c.serveHTTP))
+	return c
+}
+
+func (c *fakeCollector) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	data, _ := ioutil.ReadAll(r.Body)
+	req := collectorRequest{header: r.Header}
+	if err := json.Unmarshal(data, &req.body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	c.mu.Lock()
+	c.reqs = append(c.reqs, req)
+	status := http.StatusOK
+	if len(c.statuses) > 0 {
+		status = c.statuses[0]
+		c.statuses = c.statuses[1:]
+	}
+	c.mu.Unlock()
+	if status != http.StatusOK {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte("{}"))
+}
+
+func (c *fakeCollector) requests() []collectorRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]collectorRequest(nil), c.reqs...)
+}
@@ -0,0 +1,38 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package otlplog
+
+import "context"
+
+// SpanContext identifies the trace span that an entry was logged in.
+type SpanContext struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+	// TraceFlags holds the W3C trace flags, like whether the trace is sampled.
+	TraceFlags byte
+}
+
+// IsValid reports whether sc has a non-zero trace ID and span ID.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != [16]byte{} && sc.SpanID != [8]byte{}
+}
+
+type spanContextKey struct{}
+
+// ContextWithSpanContext returns a Context derived from parent that carries sc.
+// Applications using an OpenTelemetry SDK will usually set
+// Options.SpanContext instead.
+func ContextWithSpanContext(parent context.Context, sc SpanContext) context.Context {
+	return context.WithValue(parent, spanContextKey{}, sc)
+}
+
+// SpanContextFromContext returns the SpanContext set by ContextWithSpanContext
+// or the zero SpanContext if none was set.
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	sc, _ := ctx.Value(spanContextKey{}).(SpanContext)
+	return sc
+}
@@ -0,0 +1,321 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package log
+
+import (
+	"container/list"
+	"context"
+	"io"
+	"sync"
+)
+
+// DefaultMaxRoutes is the number of open routes used if
+// RouterOptions.MaxRoutes is zero.
+const DefaultMaxRoutes = 64
+
+// RouterOptions is the set of optional arguments to NewRouter.
+type RouterOptions struct {
+	// Default receives entries whose Context has no route key and entries for
+	// routes whose Logger could not be created. If nil, those entries are
+	// dropped.
+	Default Logger
+
+	// MaxRoutes is the maximum number of route Loggers to keep open.
+	// When a new route would exceed it, the least recently used route is
+	// closed. If zero, DefaultMaxRoutes is used.
+	MaxRoutes int
+
+	// ErrorFunc is called if not nil when creating or closing a route Logger
+	// fails. It must be safe to call from multiple goroutines.
+	ErrorFunc func(context.Context, error)
+}
+
+// A Router is a Logger that sends each entry to a Logger chosen by a key in
+// the entry's Context, like a tenant or job ID. Route Loggers are created on
+// first use and closed when they are evicted or the Router is closed.
+// It is safe to call a Router's methods from multiple goroutines.
+type Router struct {
+	key       func(context.Context) string
+	newLogger func(key string) (Logger, error)
+	def       Logger
+	max       int
+	errFunc   func(context.Context, error)
+
+	mu     sync.Mutex
+	routes map[string]*list.Element // values are *route
+	lru    list.List                // front is most recently used
+	// draining is the set of evicted routes whose Loggers have not been closed.
+	draining map[*route]struct{}
+	closed   bool
+	// closers counts the evicted route Loggers being closed outside of Close.
+	closers sync.WaitGroup
+}
+
+// A route is an entry in a Router's routes. Its Logger is created outside
+// the Router's lock, so a route is added before its Logger is ready.
+type route struct {
+	key   string
+	ready chan struct{} // closed when the Logger is created or creation fails
+	ref   *loggerRef    // nil if creation failed
+
+	// The following fields are guarded by Router.mu.
+
+	// evicted is true if the route was evicted before its Logger was ready.
+	// The goroutine that created the Logger releases it instead.
+	evicted bool
+	// closing is true if Router.Close closes the route's Logger.
+	closing bool
+}
+
+// NewRouter returns a new Router. key returns the route key for a Context,
+// or the empty string to use the default route. newLogger is called with
+// a route key the first time an entry is logged for it or after the route
+// has been evicted. If the returned Logger implements io.Closer, it is closed
+// when the route is evicted, after its calls to Log have returned.
+// newLogger and Close are called without blocking other routes,
+// but newLogger must not log to the Router with the key it is creating.
+func NewRouter(key func(context.Context) string, newLogger func(key string) (Logger, error), opts *RouterOptions) *Router {
+	r := &Router{
+		key:       key,
+		newLogger: newLogger,
+		def:       Discard,
+		max:       DefaultMaxRoutes,
+		routes:    make(map[string]*list.Element),
+		draining:  make(map[*route]struct{}),
+	}
+	if opts != nil {
+		if opts.Default != nil {
+			r.def = opts.Default
+		}
+		if opts.MaxRoutes > 0 {
+			r.max = opts.MaxRoutes
+		}
+		r.errFunc = opts.ErrorFunc
+	}
+	return r
+}
+
+// Log sends the entry to the Logger for the route key in ctx.
+func (r *Router) Log(ctx context.Context, ent Entry) {
+	rt := r.acquire(ctx)
+	if rt == nil {
+		r.def.Log(ctx, ent)
+		return
+	}
+	defer r.release(ctx, rt)
+	rt.ref.logger.Log(ctx, ent)
+}
+
+// LogEnabled returns the result of the default route's LogEnabled method.
+// Callers with a Context should use LogEnabledContext.
+func (r *Router) LogEnabled(ent Entry) bool {
+	return r.def.LogEnabled(ent)
+}
+
+// LogEnabledContext returns the result of LogEnabledContext for the Logger
+// for the route key in ctx, creating it if necessary.
+func (r *Router) LogEnabledContext(ctx context.Context, ent Entry) bool {
+	rt := r.acquire(ctx)
+	if rt == nil {
+		return LogEnabledContext(ctx, r.def, ent)
+	}
+	defer r.release(ctx, rt)
+	return LogEnabledContext(ctx, rt.ref.logger, ent)
+}
+
+// Close closes all open route Loggers after their calls to Log return
+// and returns the first error from closing them. Entries logged after Close
+// are sent to the default route.
+func (r *Router) Close() error {
+	r.mu.Lock()
+	r.closed = true
+	var installed, draining []*route
+	for e := r.lru.Front(); e != nil; e = e.Next() {
+		rt := e.Value.(*route)
+		rt.closing = true
+		installed = append(installed, rt)
+	}
+	for rt := range r.draining {
+		rt.closing = true
+		draining = append(draining, rt)
+	}
+	r.routes = nil
+	r.lru.Init()
+	r.draining = nil
+	r.mu.Unlock()
+
+	var firstErr error
+	closeRoute := func(rt *route) {
+		<-rt.ref.drained
+		if err := closeLogger(rt.ref.logger); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	for _, rt := range installed {
+		<-rt.ready
+		if rt.ref == nil {
+			continue
+		}
+		rt.ref.release()
+		closeRoute(rt)
+	}
+	for _, rt := range draining {
+		closeRoute(rt)
+	}
+	r.closers.Wait()
+	return firstErr
+}
+
+// acquire returns the route for ctx with its call count incremented
+// or nil if the entry should be sent to the default route.
+func (r *Router) acquire(ctx context.Context) *route {
+	key := r.key(ctx)
+	if key == "" {
+		return nil
+	}
+	for {
+		r.mu.Lock()
+		if r.closed {
+			r.mu.Unlock()
+			return nil
+		}
+		e := r.routes[key]
+		if e == nil {
+			break
+		}
+		r.lru.MoveToFront(e)
+		rt := e.Value.(*route)
+		select {
+		case <-rt.ready:
+			// An installed route always holds a reference,
+			// so acquire cannot fail.
+			rt.ref.acquire()
+			r.mu.Unlock()
+			return rt
+		default:
+		}
+		r.mu.Unlock()
+
+		<-rt.ready
+		if rt.ref == nil {
+			// Creation failed and was reported by the creating goroutine.
+			return nil
+		}
+		if rt.ref.acquire() {
+			return rt
+		}
+		// The route was evicted and closed while waiting. Try again.
+	}
+
+	// Add a placeholder so that other goroutines wait for this one
+	// to create the Logger instead of creating their own.
+	rt := &route{key: key, ready: make(chan struct{})}
+	e := r.lru.PushFront(rt)
+	r.routes[key] = e
+	evicted := r.evictLocked()
+	r.mu.Unlock()
+	r.releaseEvicted(ctx, evicted)
+
+	l, err := r.newLogger(key)
+
+	r.mu.Lock()
+	if err != nil {
+		close(rt.ready)
+		if !rt.evicted && !rt.closing {
+			r.lru.Remove(e)
+			delete(r.routes, key)
+		}
+		r.mu.Unlock()
+		r.error(ctx, err)
+		return nil
+	}
+	rt.ref = newLoggerRef(l)
+	rt.ref.acquire()
+	close(rt.ready)
+	wasEvicted := rt.evicted
+	if wasEvicted && !rt.closing {
+		r.draining[rt] = struct{}{}
+	}
+	r.mu.Unlock()
+	if wasEvicted {
+		// Drop the route's own reference. The Logger is closed
+		// when the caller releases its reference.
+		rt.ref.release()
+	}
+	return rt
+}
+
+// evictLocked removes the least recently used routes until there are
+// at most r.max. It returns the evicted routes whose Loggers are ready,
+// which must be passed to releaseEvicted after unlocking r.mu.
+func (r *Router) evictLocked() []*route {
+	var evicted []*route
+	for r.lru.Len() > r.max {
+		rt := r.lru.Remove(r.lru.Back()).(*route)
+		delete(r.routes, rt.key)
+		select {
+		case <-rt.ready:
+			if rt.ref != nil {
+				r.draining[rt] = struct{}{}
+				evicted = append(evicted, rt)
+			}
+		default:
+			rt.evicted = true
+		}
+	}
+	return evicted
+}
+
+// releaseEvicted drops the routes' own references to their Loggers,
+// closing the Loggers that have no calls in progress.
+func (r *Router) releaseEvicted(ctx context.Context, evicted []*route) {
+	for _, rt := range evicted {
+		if rt.ref.release() {
+			r.closeEvicted(ctx, rt)
+		}
+	}
+}
+
+// release decrements the call count of rt and closes its Logger
+// if it was evicted and this was the last call.
+func (r *Router) release(ctx context.Context, rt *route) {
+	if rt.ref.release() {
+		r.closeEvicted(ctx, rt)
+	}
+}
+
+// closeEvicted closes the Logger of an evicted route whose calls have
+// finished, unless Close is closing it.
+func (r *Router) closeEvicted(ctx context.Context, rt *route) {
+	r.mu.Lock()
+	if rt.closing {
+		// Close waits for the calls to finish and closes the Logger.
+		r.mu.Unlock()
+		return
+	}
+	delete(r.draining, rt)
+	r.closers.Add(1)
+	r.mu.Unlock()
+	defer r.closers.Done()
+	if err := closeLogger(rt.ref.logger); err != nil {
+		r.error(ctx, err)
+	}
+}
+
+func (r *Router) error(ctx context.Context, err error) {
+	if r.errFunc != nil {
+		r.errFunc(ctx, err)
+	}
+}
+
+func closeLogger(l Logger) error {
+	c, ok := l.(io.Closer)
+	if !ok {
+		return nil
+	}
+	return c.Close()
+}
@@ -0,0 +1,361 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package log
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var _ interface {
+	Logger
+	ContextLogEnabler
+} = new(Router)
+
+type tenantKey struct{}
+
+func tenantFromContext(ctx context.Context) string {
+	s, _ := ctx.Value(tenantKey{}).(string)
+	return s
+}
+
+func withTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// routeSinks creates a msgLogger for each route key and records the order in
+// which keys were created and closed.
+type routeSinks struct {
+	mu      sync.Mutex
+	sinks   map[string][]*msgLogger
+	created []string
+	closed  []string
+}
+
+func (rs *routeSinks) newLogger(key string) (Logger, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if key == "bad" {
+		return nil, errors.New("cannot create bad")
+	}
+	if rs.sinks == nil {
+		rs.sinks = make(map[string][]*msgLogger)
+	}
+	l := &msgLogger{onClose: func() {
+		rs.mu.Lock()
+		rs.closed = append(rs.closed, key)
+		rs.mu.Unlock()
+	}}
+	rs.sinks[key] = append(rs.sinks[key], l)
+	rs.created = append(rs.created, key)
+	return l, nil
+}
+
+// messages returns the messages logged to all the Loggers created for key.
+func (rs *routeSinks) messages(key string) []string {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	var msgs []string
+	for _, l := range rs.sinks[key] {
+		msgs = append(msgs, l.msgs...)
+	}
+	return msgs
+}
+
+type msgLogger struct {
+	msgs    []string
+	onClose func()
+}
+
+func (l *msgLogger) Log(_ context.Context, ent Entry) { l.msgs = append(l.msgs, ent.Msg) }
+func (l *msgLogger) LogEnabled(Entry) bool            { return true }
+
+func (l *msgLogger) Close() error {
+	l.onClose()
+	return nil
+}
+
+func TestRouter(t *testing.T) {
+	rs := new(routeSinks)
+	def := new(msgLogger)
+	r := NewRouter(tenantFromContext, rs.newLogger, &RouterOptions{Default: def})
+	ctx := context.Background()
+	r.Log(withTenant(ctx, "a"), Entry{Msg: "a1"})
+	r.Log(withTenant(ctx, "b"), Entry{Msg: "b1"})
+	r.Log(ctx, Entry{Msg: "default"})
+	r.Log(withTenant(ctx, "a"), Entry{Msg: "a2"})
+
+	if diff := cmp.Diff([]string{"a", "b"}, rs.created); diff != "" {
+		t.Errorf("created routes (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"a1", "a2"}, rs.messages("a")); diff != "" {
+		t.Errorf("route a messages (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"b1"}, rs.messages("b")); diff != "" {
+		t.Errorf("route b messages (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"default"}, def.msgs); diff != "" {
+		t.Errorf("default route messages (-want +got):\n%s", diff)
+	}
+
+	if err := r.Close(); err != nil {
+		t.Error("Close:", err)
+	}
+	if diff := cmp.Diff([]string{"a", "b"}, rs.closed); diff != "" {
+		t.Errorf("closed routes (-want +got):\n%s", diff)
+	}
+	r.Log(withTenant(ctx, "a"), Entry{Msg: "after close"})
+	if diff := cmp.Diff([]string{"default", "after close"}, def.msgs); diff != "" {
+		t.Errorf("default route messages after Close (-want +got):\n%s", diff)
+	}
+}
+
+func TestRouterEviction(t *testing.T) {
+	rs := new(routeSinks)
+	r := NewRouter(tenantFromContext, rs.newLogger, &RouterOptions{MaxRoutes: 2})
+	ctx := context.Background()
+	for _, key := range []string{"a", "b", "a", "c", "b"} {
+		r.Log(withTenant(ctx, key), Entry{Msg: key})
+	}
+	// "b" is least recently used when "c" is created, then "a" when "b" is
+	// created again.
+	if diff := cmp.Diff([]string{"a", "b", "c", "b"}, rs.created); diff != "" {
+		t.Errorf("created routes (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"b", "a"}, rs.closed); diff != "" {
+		t.Errorf("closed routes (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"b", "b"}, rs.messages("b")); diff != "" {
+		t.Errorf("route b messages (-want +got):\n%s", diff)
+	}
+}
+
+func TestRouterEvictionWaitsForLog(t *testing.T) {
+	closed := make(chan string, 2)
+	logging := make(chan struct{})
+	unblock := make(chan struct{})
+	r := NewRouter(tenantFromContext, func(key string) (Logger, error) {
+		if key != "slow" {
+			return Discard, nil
+		}
+		return &blockingCloser{logging: logging, unblock: unblock, closed: closed}, nil
+	}, &RouterOptions{MaxRoutes: 1})
+	ctx := context.Background()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.Log(withTenant(ctx, "slow"), Entry{Msg: "Hello"})
+	}()
+	<-logging
+	r.Log(withTenant(ctx, "fast"), Entry{Msg: "Evict"})
+	select {
+	case <-closed:
+		t.Fatal("evicted route closed while Log in progress")
+	default:
+	}
+	close(unblock)
+	<-done
+	select {
+	case <-closed:
+	default:
+		t.Error("evicted route not closed after Log returned")
+	}
+}
+
+type blockingCloser struct {
+	logging chan<- struct{}
+	unblock <-chan struct{}
+	closed  chan<- string
+	err     error // returned from Close
+}
+
+func (b *blockingCloser) Log(context.Context, Entry) {
+	close(b.logging)
+	<-b.unblock
+}
+
+func (b *blockingCloser) LogEnabled(Entry) bool { return true }
+
+func (b *blockingCloser) Close() error {
+	b.closed <- "slow"
+	return b.err
+}
+
+func TestRouterNewLoggerError(t *testing.T) {
+	rs := new(routeSinks)
+	def := new(msgLogger)
+	var errs []error
+	r := NewRouter(tenantFromContext, rs.newLogger, &RouterOptions{
+		Default:   def,
+		ErrorFunc: func(_ context.Context, err error) { errs = append(errs, err) },
+	})
+	ctx := withTenant(context.Background(), "bad")
+	r.Log(ctx, Entry{Msg: "Hello"})
+	r.Log(ctx, Entry{Msg: "World"})
+	if diff := cmp.Diff([]string{"Hello", "World"}, def.msgs); diff != "" {
+		t.Errorf("default route messages (-want +got):\n%s", diff)
+	}
+	if len(errs) != 2 {
+		t.Errorf("ErrorFunc called %d times; want 2", len(errs))
+	}
+}
+
+func TestRouterLogEnabledContext(t *testing.T) {
+	r := NewRouter(tenantFromContext, func(key string) (Logger, error) {
+		return &LevelFilter{Min: Warn, Output: new(msgLogger)}, nil
+	}, &RouterOptions{Default: new(msgLogger)})
+	ctx := context.Background()
+	if !r.LogEnabledContext(ctx, Entry{Level: Info}) {
+		t.Error("LogEnabledContext(ctx, Info) = false for default route; want true")
+	}
+	if r.LogEnabledContext(withTenant(ctx, "a"), Entry{Level: Info}) {
+		t.Error("LogEnabledContext(ctx, Info) = true for route a; want false")
+	}
+	if !r.LogEnabledContext(withTenant(ctx, "a"), Entry{Level: Warn}) {
+		t.Error("LogEnabledContext(ctx, Warn) = false for route a; want true")
+	}
+	if !IsEnabledContext(WithLogger(ctx, r), Info) {
+		t.Error("IsEnabledContext(ctx, Info) = false for default route; want true")
+	}
+}
+
+func TestRouterCloseWaitsForLog(t *testing.T) {
+	closed := make(chan string, 1)
+	logging := make(chan struct{})
+	unblock := make(chan struct{})
+	closeErr := errors.New("flush failed")
+	r := NewRouter(tenantFromContext, func(key string) (Logger, error) {
+		return &blockingCloser{
+			logging: logging,
+			unblock: unblock,
+			closed:  closed,
+			err:     closeErr,
+		}, nil
+	}, &RouterOptions{
+		ErrorFunc: func(_ context.Context, err error) {
+			t.Errorf("ErrorFunc(%v) called; want error returned from Close", err)
+		},
+	})
+	ctx := context.Background()
+	logDone := make(chan struct{})
+	go func() {
+		defer close(logDone)
+		r.Log(withTenant(ctx, "slow"), Entry{Msg: "Hello"})
+	}()
+	<-logging
+
+	closeDone := make(chan error, 1)
+	go func() {
+		closeDone <- r.Close()
+	}()
+	select {
+	case err := <-closeDone:
+		t.Fatalf("Close returned %v while Log in progress", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(unblock)
+	<-logDone
+	if err := <-closeDone; err != closeErr {
+		t.Errorf("Close() = %v; want %v", err, closeErr)
+	}
+	select {
+	case <-closed:
+	default:
+		t.Error("route Logger not closed when Close returned")
+	}
+}
+
+func TestRouterNewLoggerDoesNotBlock(t *testing.T) {
+	creating := make(chan struct{})
+	unblock := make(chan struct{})
+	fast := new(msgLogger)
+	r := NewRouter(tenantFromContext, func(key string) (Logger, error) {
+		if key == "slow" {
+			close(creating)
+			<-unblock
+			return new(msgLogger), nil
+		}
+		return fast, nil
+	}, nil)
+	ctx := context.Background()
+	slowDone := make(chan struct{})
+	go func() {
+		defer close(slowDone)
+		r.Log(withTenant(ctx, "slow"), Entry{Msg: "Hello"})
+	}()
+	<-creating
+
+	fastDone := make(chan struct{})
+	go func() {
+		defer close(fastDone)
+		r.Log(withTenant(ctx, "fast"), Entry{Msg: "Hello"})
+	}()
+	select {
+	case <-fastDone:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Log to another route blocked while creating a route Logger")
+	}
+	close(unblock)
+	<-slowDone
+	if diff := cmp.Diff([]string{"Hello"}, fast.msgs); diff != "" {
+		t.Errorf("fast route messages (-want +got):\n%s", diff)
+	}
+}
+
+func TestRouterEvictionCloseDoesNotBlock(t *testing.T) {
+	closing := make(chan struct{})
+	unblock := make(chan struct{})
+	r := NewRouter(tenantFromContext, func(key string) (Logger, error) {
+		if key == "a" {
+			return &slowCloser{closing: closing, unblock: unblock}, nil
+		}
+		return new(msgLogger), nil
+	}, &RouterOptions{MaxRoutes: 2})
+	ctx := context.Background()
+	r.Log(withTenant(ctx, "a"), Entry{Msg: "Hello"})
+	r.Log(withTenant(ctx, "b"), Entry{Msg: "Hello"})
+
+	// Creating "c" evicts "a", whose Close blocks.
+	evictDone := make(chan struct{})
+	go func() {
+		defer close(evictDone)
+		r.Log(withTenant(ctx, "c"), Entry{Msg: "Hello"})
+	}()
+	<-closing
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.Log(withTenant(ctx, "b"), Entry{Msg: "Hello"})
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Log to another route blocked while closing an evicted route Logger")
+	}
+	close(unblock)
+	<-evictDone
+}
+
+type slowCloser struct {
+	closing chan<- struct{}
+	unblock <-chan struct{}
+}
+
+func (*slowCloser) Log(context.Context, Entry) {}
+func (*slowCloser) LogEnabled(Entry) bool      { return true }
+
+func (c *slowCloser) Close() error {
+	close(c.closing)
+	<-c.unblock
+	return nil
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spoollog_test
+
+import (
+	"context"
+	"os"
+
+	"zombiezen.com/go/log"
+	"zombiezen.com/go/log/lokilog"
+	"zombiezen.com/go/log/spoollog"
+)
+
+func ExampleOpen() {
+	// Any sink with a Send method can be used with a spool.
+	// The Loki logger's own buffer is bypassed.
+	loki := lokilog.New("http://localhost:3100", nil)
+	defer loki.Close()
+
+	logger, err := spoollog.Open("/var/spool/myapp/log", loki, nil)
+	if err != nil {
+		log.Errorf(context.Background(), "%v", err)
+		os.Exit(1)
+	}
+	defer logger.Close()
+	log.SetDefault(logger)
+
+	log.Infof(context.Background(), "Hello, World!")
+}
@@ -0,0 +1,195 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spoollog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"zombiezen.com/go/log"
+)
+
+// segmentExt is the file name extension of segment files.
+// Segment files are named by their zero-padded sequence number
+// so that lexical order is the same as creation order.
+const segmentExt = ".spool"
+
+// A segment is a file of newline-delimited JSON records.
+type segment struct {
+	seq   uint64
+	size  int64
+	count int // number of records, including any malformed ones
+}
+
+func segmentName(seq uint64) string {
+	return fmt.Sprintf("%020d%s", seq, segmentExt)
+}
+
+func (seg *segment) path(dir string) string {
+	return filepath.Join(dir, segmentName(seg.seq))
+}
+
+// scanSegments returns the segments in dir in creation order.
+func scanSegments(dir string) ([]*segment, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var segs []*segment
+	for _, info := range infos {
+		name := info.Name()
+		if !info.Mode().IsRegular() || !strings.HasSuffix(name, segmentExt) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, segmentExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		segs = append(segs, &segment{
+			seq:   seq,
+			size:  int64(len(data)),
+			count: len(splitRecords(data)),
+		})
+	}
+	sort.Slice(segs, func(i, j int) bool {
+		return segs[i].seq < segs[j].seq
+	})
+	return segs, nil
+}
+
+// splitRecords splits segment data into records. A final record without a
+// trailing newline (from an interrupted write) is still returned so that it
+// counts as a malformed record.
+func splitRecords(data []byte) [][]byte {
+	var records [][]byte
+	for len(data) > 0 {
+		i := bytes.IndexByte(data, '\n')
+		if i == -1 {
+			records = append(records, data)
+			break
+		}
+		if i > 0 {
+			records = append(records, data[:i])
+		}
+		data = data[i+1:]
+	}
+	return records
+}
+
+// readRecords reads up to max records from r in the same manner as
+// splitRecords. It returns the records along with the number of bytes read.
+func readRecords(r *bufio.Reader, max int) (_ [][]byte, n int64, _ error) {
+	var records [][]byte
+	for len(records) < max {
+		line, err := r.ReadBytes('\n')
+		n += int64(len(line))
+		if err == io.EOF {
+			if len(line) > 0 {
+				records = append(records, line)
+			}
+			break
+		}
+		if err != nil {
+			return records, n, err
+		}
+		if len(line) > 1 {
+			records = append(records, line[:len(line)-1])
+		}
+	}
+	return records, n, nil
+}
+
+// cursorName is the name of the file in the spool directory that records
+// how much of the oldest segment has been delivered, so that a reopened spool
+// does not send those entries again.
+const cursorName = "cursor"
+
+// writeCursor records that the bytes of segment seq before offset
+// have been delivered.
+func writeCursor(dir string, seq uint64, offset int64) error {
+	data := strconv.FormatUint(seq, 10) + " " + strconv.FormatInt(offset, 10) + "\n"
+	tmp := filepath.Join(dir, cursorName+".tmp")
+	if err := ioutil.WriteFile(tmp, []byte(data), 0o666); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(dir, cursorName))
+}
+
+// readCursor returns the position saved by writeCursor.
+// ok is false if no position was saved or the file is malformed.
+func readCursor(dir string) (seq uint64, offset int64, ok bool) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, cursorName))
+	if err != nil {
+		return 0, 0, false
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) != 2 {
+		return 0, 0, false
+	}
+	seq, err = strconv.ParseUint(fields[0], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	offset, err = strconv.ParseInt(fields[1], 10, 64)
+	if err != nil || offset < 0 {
+		return 0, 0, false
+	}
+	return seq, offset, true
+}
+
+// record is the on-disk representation of a log.Entry.
+type record struct {
+	Time  time.Time `json:"t"`
+	Level log.Level `json:"l"`
+	File  string    `json:"f,omitempty"`
+	Line  int       `json:"n,omitempty"`
+	Msg   string    `json:"m"`
+}
+
+func appendRecord(buf []byte, ent log.Entry) ([]byte, error) {
+	data, err := json.Marshal(record{
+		Time:  ent.Time,
+		Level: ent.Level,
+		File:  ent.File,
+		Line:  ent.Line,
+		Msg:   ent.Msg,
+	})
+	if err != nil {
+		return buf, err
+	}
+	buf = append(buf, data...)
+	buf = append(buf, '\n')
+	return buf, nil
+}
+
+func parseRecord(data []byte) (log.Entry, error) {
+	var r record
+	if err := json.Unmarshal(data, &r); err != nil {
+		return log.Entry{}, err
+	}
+	return log.Entry{
+		Time:  r.Time,
+		Level: r.Level,
+		File:  r.File,
+		Line:  r.Line,
+		Msg:   r.Msg,
+	}, nil
+}
@@ -0,0 +1,530 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package spoollog provides a Logger that stores entries in a bounded on-disk
+// queue while a remote sink is unavailable, then replays them in order once
+// the sink recovers.
+//
+// Entries are delivered at least once: the position of the last entry
+// replayed from disk is saved after each successful Send, so if the process
+// exits between a Send and saving the position, that batch of entries is sent
+// again when the spool is reopened.
+// Context values are not persisted, so entries replayed from disk are sent
+// with a background Context.
+package spoollog
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"zombiezen.com/go/log"
+	"zombiezen.com/go/log/internal/backoff"
+	"zombiezen.com/go/log/internal/batch"
+)
+
+// A Sender delivers entries to a remote sink. Send must return an error if
+// the entries were not delivered. Send is only called from one goroutine
+// at a time.
+type Sender interface {
+	Send(ctx context.Context, entries []log.Entry) error
+}
+
+// SenderFunc is an adapter to allow the use of an ordinary function as a Sender.
+type SenderFunc func(ctx context.Context, entries []log.Entry) error
+
+// Send returns f(ctx, entries).
+func (f SenderFunc) Send(ctx context.Context, entries []log.Entry) error {
+	return f(ctx, entries)
+}
+
+// Default values for the fields of Options.
+const (
+	DefaultMaxBytes     = 64 << 20
+	DefaultSegmentBytes = 4 << 20
+)
+
+// Options is the set of optional arguments to Open.
+type Options struct {
+	// MaxBytes is the maximum total size of the spool's files. When exceeded,
+	// the oldest segment of entries is deleted. If zero, DefaultMaxBytes is used.
+	MaxBytes int64
+	// SegmentBytes is the size at which a new segment file is started.
+	// If zero, DefaultSegmentBytes or a quarter of MaxBytes is used,
+	// whichever is smaller.
+	SegmentBytes int64
+
+	// BatchSize is the maximum number of entries passed to a single call
+	// of Send. If zero, 512 is used.
+	BatchSize int
+	// FlushInterval is the maximum amount of time an entry is buffered in
+	// memory before it is sent. If zero, one second is used.
+	FlushInterval time.Duration
+	// MaxBuffer is the maximum number of entries buffered in memory.
+	// When the buffer is full, the oldest entries are dropped.
+	// If zero, 2048 is used.
+	MaxBuffer int
+	// Backoff is the initial delay between attempts to replay spooled entries.
+	// Delays double for each attempt, up to MaxBackoff.
+	// If zero, 100 milliseconds is used.
+	Backoff time.Duration
+	// MaxBackoff is the maximum delay between attempts to replay spooled
+	// entries. If zero, 30 seconds is used.
+	MaxBackoff time.Duration
+
+	// ErrorFunc is called if not nil when Send fails, when the spool cannot
+	// be written, and when entries are dropped. It must be safe to call from
+	// multiple goroutines and should be fast.
+	ErrorFunc func(context.Context, error)
+}
+
+// Logger is a log.Logger that sends entries to a Sender, writing entries to
+// disk whenever the Sender fails and replaying them in order afterward.
+// While any entries are spooled, new entries are appended to the spool.
+// Call Close to stop the Logger's background goroutines.
+type Logger struct {
+	dir          string
+	sender       Sender
+	maxBytes     int64
+	segmentBytes int64
+	batchSize    int
+	backoff      backoff.Policy
+	errFunc      func(context.Context, error)
+	q            *batch.Queue
+
+	wake     chan struct{}
+	quit     chan struct{}
+	stopped  chan struct{}
+	closeErr error
+
+	mu       sync.Mutex
+	segments []*segment // oldest first
+	cursor   int        // number of records in segments[0] already delivered
+	offset   int64      // byte offset in segments[0] of the first undelivered record
+	tail     *os.File   // open for appending to segments[len(segments)-1], or nil
+	total    int64
+	nextSeq  uint64
+	drained  chan struct{} // closed when segments becomes empty
+}
+
+// Open returns a new Logger that spools entries to the directory dir,
+// creating it if necessary. Any entries spooled by a previous Logger are
+// replayed. Only one Logger may use a directory at a time. opts may be nil,
+// in which case it is treated the same as if new(Options) were passed.
+func Open(dir string, sender Sender, opts *Options) (*Logger, error) {
+	if opts == nil {
+		opts = new(Options)
+	}
+	if err := os.MkdirAll(dir, 0o777); err != nil {
+		return nil, fmt.Errorf("open spool: %w", err)
+	}
+	segs, err := scanSegments(dir)
+	if err != nil {
+		return nil, fmt.Errorf("open spool: %w", err)
+	}
+	segs, cursor, offset, err := restoreCursor(dir, segs)
+	if err != nil {
+		return nil, fmt.Errorf("open spool: %w", err)
+	}
+	l := &Logger{
+		dir:          dir,
+		sender:       sender,
+		maxBytes:     opts.MaxBytes,
+		segmentBytes: opts.SegmentBytes,
+		batchSize:    opts.BatchSize,
+		backoff: backoff.Policy{
+			Initial: opts.Backoff,
+			Max:     opts.MaxBackoff,
+		},
+		errFunc:  opts.ErrorFunc,
+		wake:     make(chan struct{}, 1),
+		quit:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+		segments: segs,
+		cursor:   cursor,
+		offset:   offset,
+		drained:  make(chan struct{}),
+	}
+	if l.maxBytes <= 0 {
+		l.maxBytes = DefaultMaxBytes
+	}
+	if l.segmentBytes <= 0 {
+		l.segmentBytes = DefaultSegmentBytes
+		if q := l.maxBytes / 4; q < l.segmentBytes {
+			l.segmentBytes = q
+		}
+	}
+	if l.batchSize <= 0 {
+		l.batchSize = batch.DefaultBatchSize
+	}
+	for _, seg := range segs {
+		l.total += seg.size
+	}
+	if len(segs) > 0 {
+		l.nextSeq = segs[len(segs)-1].seq + 1
+	} else {
+		close(l.drained)
+	}
+	l.q = batch.New(batch.Options{
+		Send:        l.send,
+		BatchSize:   l.batchSize,
+		Interval:    opts.FlushInterval,
+		MaxBuffer:   opts.MaxBuffer,
+		MaxAttempts: 1,
+		ErrorFunc:   opts.ErrorFunc,
+	})
+	go l.replay()
+	return l, nil
+}
+
+// restoreCursor removes the segments that were fully delivered according to
+// the position saved in dir and returns the remaining segments along with the
+// delivered position in the oldest one.
+func restoreCursor(dir string, segs []*segment) (_ []*segment, cursor int, offset int64, _ error) {
+	seq, offset, ok := readCursor(dir)
+	if !ok {
+		return segs, 0, 0, nil
+	}
+	for len(segs) > 0 && segs[0].seq < seq {
+		if err := os.Remove(segs[0].path(dir)); err != nil && !os.IsNotExist(err) {
+			return nil, 0, 0, err
+		}
+		segs = segs[1:]
+	}
+	if len(segs) == 0 || segs[0].seq != seq {
+		return segs, 0, 0, nil
+	}
+	data, err := ioutil.ReadFile(segs[0].path(dir))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if offset > int64(len(data)) {
+		return segs, 0, 0, nil
+	}
+	return segs, len(splitRecords(data[:offset])), offset, nil
+}
+
+// Log buffers the entry to be sent. It does not wait for the entry to be
+// delivered or spooled.
+func (l *Logger) Log(ctx context.Context, ent log.Entry) {
+	l.q.Add(ctx, ent, 0)
+}
+
+// LogEnabled always returns true.
+func (l *Logger) LogEnabled(log.Entry) bool { return true }
+
+// Flush waits for the entries buffered in memory to be either sent or
+// spooled to disk. It does not wait for spooled entries to be replayed;
+// use Drain for that.
+func (l *Logger) Flush(ctx context.Context) error {
+	return l.q.Flush(ctx)
+}
+
+// Drain flushes the memory buffer and then waits until there are no entries
+// spooled on disk.
+func (l *Logger) Drain(ctx context.Context) error {
+	if err := l.Flush(ctx); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	drained := l.drained
+	l.mu.Unlock()
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("drain spool: %w", ctx.Err())
+	}
+}
+
+// Close sends or spools any entries buffered in memory and stops the Logger's
+// background goroutines. Spooled entries remain on disk and will be replayed
+// by the next call to Open with the same directory.
+func (l *Logger) Close() error {
+	err := l.q.Close()
+	select {
+	case <-l.quit:
+	default:
+		close(l.quit)
+	}
+	<-l.stopped
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.tail != nil {
+		if cerr := l.tail.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("close spool: %w", cerr)
+		}
+		l.tail = nil
+	}
+	return err
+}
+
+// Spooled reports the number of entries currently spooled on disk.
+func (l *Logger) Spooled() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := -l.cursor
+	for _, seg := range l.segments {
+		n += seg.count
+	}
+	return n
+}
+
+// send is called by the memory queue.
+func (l *Logger) send(ctx context.Context, items []interface{}) error {
+	entries := make([]log.Entry, len(items))
+	for i, item := range items {
+		entries[i] = item.(log.Entry)
+	}
+	l.mu.Lock()
+	spooling := len(l.segments) > 0
+	l.mu.Unlock()
+	if !spooling {
+		// Only this goroutine adds segments, so the spool stays empty
+		// (and the replay goroutine idle) while sending.
+		err := l.sender.Send(ctx, entries)
+		if err == nil {
+			return nil
+		}
+		l.reportError(fmt.Errorf("send %d entries: %w", len(entries), err))
+	}
+	if err := l.spool(entries); err != nil {
+		return batch.Permanent(err)
+	}
+	return nil
+}
+
+// spool appends entries to the newest segment.
+func (l *Logger) spool(entries []log.Entry) error {
+	var buf []byte
+	var sizes []int
+	for _, ent := range entries {
+		n := len(buf)
+		var err error
+		buf, err = appendRecord(buf, ent)
+		if err != nil {
+			return fmt.Errorf("spool entries: %w", err)
+		}
+		sizes = append(sizes, len(buf)-n)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	wasEmpty := len(l.segments) == 0
+	for len(buf) > 0 {
+		if l.tail == nil || l.segments[len(l.segments)-1].size >= l.segmentBytes {
+			if err := l.rotateLocked(); err != nil {
+				return fmt.Errorf("spool entries: %w", err)
+			}
+		}
+		seg := l.segments[len(l.segments)-1]
+		// Write as many records as fit in the segment (at least one).
+		n, count := 0, 0
+		for count < len(sizes) && (count == 0 || seg.size+int64(n+sizes[count]) <= l.segmentBytes) {
+			n += sizes[count]
+			count++
+		}
+		if _, err := l.tail.Write(buf[:n]); err != nil {
+			return fmt.Errorf("spool entries: %w", err)
+		}
+		seg.size += int64(n)
+		seg.count += count
+		l.total += int64(n)
+		buf, sizes = buf[n:], sizes[count:]
+	}
+	l.evictLocked()
+	if wasEmpty {
+		l.drained = make(chan struct{})
+		select {
+		case l.wake <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// rotateLocked starts a new segment file.
+func (l *Logger) rotateLocked() error {
+	if l.tail != nil {
+		if err := l.tail.Close(); err != nil {
+			return err
+		}
+		l.tail = nil
+	}
+	seg := &segment{seq: l.nextSeq}
+	f, err := os.OpenFile(seg.path(l.dir), os.O_WRONLY|os.O_CREATE|os.O_EXCL|os.O_APPEND, 0o666)
+	if err != nil {
+		return err
+	}
+	l.nextSeq++
+	l.tail = f
+	l.segments = append(l.segments, seg)
+	return nil
+}
+
+// evictLocked deletes the oldest segments until the spool is within MaxBytes.
+// The newest segment is never evicted.
+func (l *Logger) evictLocked() {
+	for l.total > l.maxBytes && len(l.segments) > 1 {
+		seg := l.segments[0]
+		dropped := seg.count - l.cursor
+		l.removeHeadLocked()
+		l.reportError(fmt.Errorf("spool full: dropped %d entries", dropped))
+	}
+}
+
+// removeHeadLocked deletes the oldest segment.
+func (l *Logger) removeHeadLocked() {
+	seg := l.segments[0]
+	if len(l.segments) == 1 && l.tail != nil {
+		l.tail.Close()
+		l.tail = nil
+	}
+	if err := os.Remove(seg.path(l.dir)); err != nil && !os.IsNotExist(err) {
+		l.reportError(fmt.Errorf("spool: %w", err))
+	}
+	l.total -= seg.size
+	l.segments[0] = nil
+	l.segments = l.segments[1:]
+	l.cursor = 0
+	l.offset = 0
+	if err := os.Remove(filepath.Join(l.dir, cursorName)); err != nil && !os.IsNotExist(err) {
+		l.reportError(fmt.Errorf("spool: %w", err))
+	}
+	if len(l.segments) == 0 {
+		close(l.drained)
+	}
+}
+
+// replay sends spooled entries until the Logger is closed.
+func (l *Logger) replay() {
+	defer close(l.stopped)
+	ctx := context.Background()
+	attempt := 0
+	for {
+		entries, seq, consumed, nbytes, err := l.readHead()
+		if errors.Is(err, errEmpty) {
+			select {
+			case <-l.wake:
+				continue
+			case <-l.quit:
+				return
+			}
+		}
+		if err == nil && len(entries) > 0 {
+			err = l.sender.Send(ctx, entries)
+			if err != nil {
+				err = fmt.Errorf("replay %d entries: %w", len(entries), err)
+			}
+		}
+		if err != nil {
+			l.reportError(err)
+			attempt++
+			t := time.NewTimer(l.backoff.Delay(attempt))
+			select {
+			case <-t.C:
+			case <-l.quit:
+				t.Stop()
+				return
+			}
+			continue
+		}
+		attempt = 0
+		l.advance(seq, consumed, nbytes)
+	}
+}
+
+var errEmpty = errors.New("spool empty")
+
+// readHead returns the next batch of undelivered entries from the oldest
+// segment along with the number of records and bytes they span.
+// Malformed records are reported and skipped.
+func (l *Logger) readHead() (_ []log.Entry, seq uint64, consumed int, nbytes int64, _ error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for len(l.segments) > 0 {
+		seg := l.segments[0]
+		if l.cursor >= seg.count {
+			if len(l.segments) == 1 {
+				// Fully delivered newest segment: start afresh.
+				l.removeHeadLocked()
+				return nil, 0, 0, 0, errEmpty
+			}
+			l.removeHeadLocked()
+			continue
+		}
+		max := seg.count - l.cursor
+		if max > l.batchSize {
+			max = l.batchSize
+		}
+		records, n, err := l.readSegmentLocked(seg, max)
+		if err != nil {
+			return nil, seg.seq, 0, 0, fmt.Errorf("replay: %w", err)
+		}
+		entries := make([]log.Entry, 0, len(records))
+		for _, r := range records {
+			ent, err := parseRecord(r)
+			if err != nil {
+				l.reportError(fmt.Errorf("replay: skipping malformed entry: %w", err))
+				continue
+			}
+			entries = append(entries, ent)
+		}
+		if len(entries) == 0 {
+			l.cursor += len(records)
+			l.offset += n
+			if len(records) == 0 {
+				// File is shorter than expected; skip the rest.
+				l.cursor = seg.count
+			}
+			continue
+		}
+		return entries, seg.seq, len(records), n, nil
+	}
+	return nil, 0, 0, 0, errEmpty
+}
+
+// readSegmentLocked reads up to max records from seg starting at l.offset.
+func (l *Logger) readSegmentLocked(seg *segment, max int) (_ [][]byte, n int64, err error) {
+	f, err := os.Open(seg.path(l.dir))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+	if _, err := f.Seek(l.offset, io.SeekStart); err != nil {
+		return nil, 0, err
+	}
+	return readRecords(bufio.NewReader(f), max)
+}
+
+// advance marks n records spanning nbytes bytes of segment seq as delivered
+// and saves the position.
+func (l *Logger) advance(seq uint64, n int, nbytes int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.segments) == 0 || l.segments[0].seq != seq {
+		// Segment was evicted while sending.
+		return
+	}
+	l.cursor += n
+	l.offset += nbytes
+	if err := writeCursor(l.dir, seq, l.offset); err != nil {
+		l.reportError(fmt.Errorf("spool: save position: %w", err))
+	}
+}
+
+func (l *Logger) reportError(err error) {
+	if l.errFunc != nil {
+		l.errFunc(context.Background(), err)
+	}
+}
@@ -0,0 +1,342 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spoollog
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"zombiezen.com/go/log"
+	"zombiezen.com/go/log/lokilog"
+	"zombiezen.com/go/log/otlplog"
+)
+
+var _ log.Logger = new(Logger)
+
+func TestLogger(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Healthy", func(t *testing.T) {
+		dir := tempDir(t)
+		defer os.RemoveAll(dir)
+		sender := new(fakeSender)
+		l, err := Open(dir, sender, &Options{FlushInterval: time.Hour})
+		if err != nil {
+			t.Fatal(err)
+		}
+		logMessages(l, 0, 3)
+		if err := l.Flush(ctx); err != nil {
+			t.Error("Flush:", err)
+		}
+		if n := l.Spooled(); n != 0 {
+			t.Errorf("Spooled() = %d; want 0", n)
+		}
+		if err := l.Close(); err != nil {
+			t.Error("Close:", err)
+		}
+		if diff := cmp.Diff(messages(0, 3), sender.messages()); diff != "" {
+			t.Errorf("sent (-want +got):\n%s", diff)
+		}
+		if files := listDir(t, dir); len(files) != 0 {
+			t.Errorf("spool directory contains %q; want empty", files)
+		}
+	})
+
+	t.Run("Outage", func(t *testing.T) {
+		dir := tempDir(t)
+		defer os.RemoveAll(dir)
+		sender := new(fakeSender)
+		sender.setFailing(true)
+		l, err := Open(dir, sender, &Options{
+			FlushInterval: time.Hour,
+			BatchSize:     2,
+			Backoff:       time.Millisecond,
+			MaxBackoff:    5 * time.Millisecond,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer l.Close()
+		logMessages(l, 0, 5)
+		if err := l.Flush(ctx); err != nil {
+			t.Error("Flush:", err)
+		}
+		if n := l.Spooled(); n != 5 {
+			t.Errorf("Spooled() = %d; want 5", n)
+		}
+		// Entries logged while spooled must be queued behind spooled entries.
+		logMessages(l, 5, 7)
+		if err := l.Flush(ctx); err != nil {
+			t.Error("Flush:", err)
+		}
+
+		sender.setFailing(false)
+		drainCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		if err := l.Drain(drainCtx); err != nil {
+			t.Fatal("Drain:", err)
+		}
+		if diff := cmp.Diff(messages(0, 7), sender.messages()); diff != "" {
+			t.Errorf("sent (-want +got):\n%s", diff)
+		}
+
+		// Back to sending directly.
+		logMessages(l, 7, 8)
+		if err := l.Flush(ctx); err != nil {
+			t.Error("Flush:", err)
+		}
+		if diff := cmp.Diff(messages(0, 8), sender.messages()); diff != "" {
+			t.Errorf("sent (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Restart", func(t *testing.T) {
+		dir := tempDir(t)
+		defer os.RemoveAll(dir)
+		sender1 := new(fakeSender)
+		sender1.setFailing(true)
+		l1, err := Open(dir, sender1, &Options{
+			FlushInterval: time.Hour,
+			Backoff:       time.Hour,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		logMessages(l1, 0, 4)
+		if err := l1.Close(); err != nil {
+			t.Error("Close:", err)
+		}
+
+		sender2 := new(fakeSender)
+		l2, err := Open(dir, sender2, &Options{FlushInterval: time.Hour})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer l2.Close()
+		drainCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		if err := l2.Drain(drainCtx); err != nil {
+			t.Fatal("Drain:", err)
+		}
+		if diff := cmp.Diff(messages(0, 4), sender2.messages()); diff != "" {
+			t.Errorf("sent (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("RestartAfterPartialReplay", func(t *testing.T) {
+		dir := tempDir(t)
+		defer os.RemoveAll(dir)
+		// The sender fails until given a quota, then accepts that many
+		// entries and fails again.
+		var mu sync.Mutex
+		quota := 0
+		var sent []log.Entry
+		sender1 := SenderFunc(func(ctx context.Context, entries []log.Entry) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if len(entries) > quota {
+				return errors.New("sink unavailable")
+			}
+			quota -= len(entries)
+			sent = append(sent, entries...)
+			return nil
+		})
+		l1, err := Open(dir, sender1, &Options{
+			FlushInterval: time.Hour,
+			BatchSize:     2,
+			Backoff:       time.Millisecond,
+			MaxBackoff:    time.Millisecond,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		logMessages(l1, 0, 6)
+		if err := l1.Flush(ctx); err != nil {
+			t.Error("Flush:", err)
+		}
+		mu.Lock()
+		quota = 2
+		mu.Unlock()
+		for deadline := time.Now().Add(10 * time.Second); l1.Spooled() > 4; {
+			if time.Now().After(deadline) {
+				t.Fatal("spooled entries not replayed")
+			}
+			time.Sleep(time.Millisecond)
+		}
+		if err := l1.Close(); err != nil {
+			t.Error("Close:", err)
+		}
+
+		sender2 := new(fakeSender)
+		sender2.setFailing(true)
+		l2, err := Open(dir, sender2, &Options{FlushInterval: time.Hour})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer l2.Close()
+		if n := l2.Spooled(); n != 4 {
+			t.Errorf("after reopening, Spooled() = %d; want 4", n)
+		}
+		sender2.setFailing(false)
+		drainCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		if err := l2.Drain(drainCtx); err != nil {
+			t.Fatal("Drain:", err)
+		}
+		if diff := cmp.Diff(messages(2, 6), sender2.messages()); diff != "" {
+			t.Errorf("sent after reopening (-want +got):\n%s", diff)
+		}
+		if files := listDir(t, dir); len(files) != 0 {
+			var names []string
+			for _, info := range files {
+				names = append(names, info.Name())
+			}
+			t.Errorf("spool directory contains %q after drain; want empty", names)
+		}
+	})
+
+	t.Run("Evict", func(t *testing.T) {
+		dir := tempDir(t)
+		defer os.RemoveAll(dir)
+		sender := new(fakeSender)
+		sender.setFailing(true)
+		var mu sync.Mutex
+		dropped := 0
+		l, err := Open(dir, sender, &Options{
+			MaxBytes:      1000,
+			SegmentBytes:  200,
+			FlushInterval: time.Hour,
+			Backoff:       time.Hour,
+			ErrorFunc: func(_ context.Context, err error) {
+				mu.Lock()
+				defer mu.Unlock()
+				dropped++
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		const n = 100
+		for i := 0; i < n; i++ {
+			logMessages(l, i, i+1)
+			if err := l.Flush(ctx); err != nil {
+				t.Fatal("Flush:", err)
+			}
+		}
+		if err := l.Close(); err != nil {
+			t.Error("Close:", err)
+		}
+		var total int64
+		for _, info := range listDir(t, dir) {
+			total += info.Size()
+		}
+		if total > 1000 {
+			t.Errorf("spool uses %d bytes; want <= 1000", total)
+		}
+
+		sender2 := new(fakeSender)
+		l2, err := Open(dir, sender2, &Options{FlushInterval: time.Hour})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer l2.Close()
+		drainCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		if err := l2.Drain(drainCtx); err != nil {
+			t.Fatal("Drain:", err)
+		}
+		got := sender2.messages()
+		if len(got) == 0 || len(got) >= n {
+			t.Fatalf("replayed %d entries; want in (0, %d)", len(got), n)
+		}
+		if diff := cmp.Diff(messages(n-len(got), n), got); diff != "" {
+			t.Errorf("replayed entries are not the newest (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func logMessages(l *Logger, start, end int) {
+	for i := start; i < end; i++ {
+		l.Log(context.Background(), log.Entry{
+			Msg:   strconv.Itoa(i),
+			Time:  time.Date(2020, time.June, 19, 0, 0, i, 0, time.UTC),
+			Level: log.Info,
+			File:  "foo.go",
+			Line:  i + 1,
+		})
+	}
+}
+
+func messages(start, end int) []string {
+	var msgs []string
+	for i := start; i < end; i++ {
+		msgs = append(msgs, strconv.Itoa(i))
+	}
+	return msgs
+}
+
+type fakeSender struct {
+	mu      sync.Mutex
+	failing bool
+	sent    []log.Entry
+}
+
+func (s *fakeSender) setFailing(failing bool) {
+	s.mu.Lock()
+	s.failing = failing
+	s.mu.Unlock()
+}
+
+func (s *fakeSender) Send(ctx context.Context, entries []log.Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failing {
+		return errors.New("sink unavailable")
+	}
+	s.sent = append(s.sent, entries...)
+	return nil
+}
+
+func (s *fakeSender) messages() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var msgs []string
+	for _, ent := range s.sent {
+		msgs = append(msgs, ent.Msg)
+	}
+	return msgs
+}
+
+func tempDir(t *testing.T) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "spoollog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func listDir(t *testing.T, dir string) []os.FileInfo {
+	t.Helper()
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return infos
+}
+
+var (
+	_ Sender = new(lokilog.Logger)
+	_ Sender = new(otlplog.Logger)
+)
@@ -0,0 +1,159 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package log
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BufferUntilConfigured holds the first n entries sent to the global logger
+// until SetDefault or ReplaceDefault is called, then sends them to the new
+// logger in order. Once n entries are held, a new entry replaces the oldest
+// held entry with the lowest level if the new entry's level is higher,
+// so that debug entries do not crowd out errors. Otherwise the new entry is
+// dropped. Dropped entries are counted. If the global
+// logger is not set within the timeout, the held entries are written to
+// stderr after a notice that the logger was never configured, and later
+// entries are sent to stderr as usual.
+//
+// BufferUntilConfigured is intended to be called at the start of main for
+// programs that configure logging after doing other work, like reading
+// a configuration file. It has no effect if the global logger has already been
+// set or if BufferUntilConfigured has already been called.
+//
+// The held entries are replayed after the new logger is installed, so entries
+// logged by other goroutines while SetDefault or ReplaceDefault is running may
+// reach the new logger before the held entries.
+func BufferUntilConfigured(n int, timeout time.Duration) {
+	defaultLogger.mu.Lock()
+	defer defaultLogger.mu.Unlock()
+	if defaultLogger.out.Load() != nil {
+		return
+	}
+	b := &startupBuffer{max: n, fallback: fallback}
+	b.timer = time.AfterFunc(timeout, b.expire)
+	defaultLogger.out.Store(newLoggerRef(b))
+}
+
+// startupBuffer is the global logger installed by BufferUntilConfigured.
+type startupBuffer struct {
+	max      int
+	timer    *time.Timer
+	fallback Logger // receives entries if the buffer expires
+
+	mu      sync.Mutex
+	entries []startupEntry
+	dropped int
+	// forward is the logger that entries are sent to after the buffer
+	// is replayed or expires. It is nil while entries are being buffered.
+	forward Logger
+}
+
+type startupEntry struct {
+	ctx context.Context
+	ent Entry
+}
+
+func (b *startupBuffer) Log(ctx context.Context, ent Entry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.forward != nil {
+		b.forward.Log(ctx, ent)
+		return
+	}
+	if len(b.entries) >= b.max {
+		b.dropped++
+		b.evictLocked(ent.Level)
+		if len(b.entries) >= b.max {
+			return
+		}
+	}
+	b.entries = append(b.entries, startupEntry{ctx, ent})
+}
+
+// evictLocked removes the oldest entry with the lowest level
+// if that level is below level.
+func (b *startupBuffer) evictLocked(level Level) {
+	i := -1
+	for j, e := range b.entries {
+		if e.ent.Level < level && (i == -1 || e.ent.Level < b.entries[i].ent.Level) {
+			i = j
+		}
+	}
+	if i == -1 {
+		return
+	}
+	b.entries = append(b.entries[:i], b.entries[i+1:]...)
+}
+
+// LogEnabled returns true while entries are being buffered, since the level
+// of the logger that will receive them is not yet known.
+func (b *startupBuffer) LogEnabled(ent Entry) bool {
+	return b.LogEnabledContext(context.Background(), ent)
+}
+
+func (b *startupBuffer) LogEnabledContext(ctx context.Context, ent Entry) bool {
+	b.mu.Lock()
+	forward := b.forward
+	b.mu.Unlock()
+	if forward != nil {
+		return LogEnabledContext(ctx, forward, ent)
+	}
+	return true
+}
+
+// replay sends the buffered entries to l and forwards any later entries to l.
+func (b *startupBuffer) replay(l Logger) {
+	b.timer.Stop()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.forward != nil {
+		// Already expired. Entries were written to stderr.
+		b.forward = l
+		return
+	}
+	b.flushLocked(l)
+	if b.dropped > 0 {
+		l.Log(context.Background(), Entry{
+			Msg:   "log: " + string(itoa(nil, b.dropped, -1)) + " entries were dropped before the logger was configured",
+			Time:  time.Now(),
+			Level: Warn,
+		})
+	}
+	b.forward = l
+}
+
+// expire writes the buffered entries to the fallback logger.
+func (b *startupBuffer) expire() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.forward != nil {
+		return
+	}
+	msg := "log: logger never configured; writing entries from startup to stderr"
+	if b.dropped > 0 {
+		msg += " (" + string(itoa(nil, b.dropped, -1)) + " entries dropped)"
+	}
+	b.fallback.Log(context.Background(), Entry{
+		Msg:   msg,
+		Time:  time.Now(),
+		Level: Warn,
+	})
+	b.flushLocked(b.fallback)
+	b.forward = b.fallback
+}
+
+func (b *startupBuffer) flushLocked(l Logger) {
+	for _, e := range b.entries {
+		if LogEnabledContext(e.ctx, l, e.ent) {
+			l.Log(e.ctx, e.ent)
+		}
+	}
+	b.entries = nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStartupBuffer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Replay", func(t *testing.T) {
+		b := &startupBuffer{max: 2, timer: time.NewTimer(time.Hour)}
+		b.Log(ctx, Entry{Msg: "one", Level: Info})
+		b.Log(ctx, Entry{Msg: "two", Level: Debug})
+		b.Log(ctx, Entry{Msg: "three", Level: Info})
+		buf := new(bytes.Buffer)
+		b.replay(New(buf, "", ShowLevel, nil))
+		b.Log(ctx, Entry{Msg: "four", Level: Info})
+
+		want := "INFO: one\n" +
+			"INFO: three\n" +
+			"WARN: log: 1 entries were dropped before the logger was configured\n" +
+			"INFO: four\n"
+		if diff := cmp.Diff(want, buf.String()); diff != "" {
+			t.Errorf("output (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("ReplayFull", func(t *testing.T) {
+		b := &startupBuffer{max: 2, timer: time.NewTimer(time.Hour)}
+		b.Log(ctx, Entry{Msg: "one", Level: Warn})
+		b.Log(ctx, Entry{Msg: "two", Level: Error})
+		b.Log(ctx, Entry{Msg: "three", Level: Info})
+		buf := new(bytes.Buffer)
+		b.replay(New(buf, "", ShowLevel, nil))
+
+		want := "WARN: one\n" +
+			"ERROR: two\n" +
+			"WARN: log: 1 entries were dropped before the logger was configured\n"
+		if diff := cmp.Diff(want, buf.String()); diff != "" {
+			t.Errorf("output (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("ReplayFiltered", func(t *testing.T) {
+		b := &startupBuffer{max: 10, timer: time.NewTimer(time.Hour)}
+		b.Log(ctx, Entry{Msg: "debug", Level: Debug})
+		b.Log(ctx, Entry{Msg: "info", Level: Info})
+		buf := new(bytes.Buffer)
+		b.replay(&LevelFilter{Min: Info, Output: New(buf, "", 0, nil)})
+		if got, want := buf.String(), "info\n"; got != want {
+			t.Errorf("output = %q; want %q", got, want)
+		}
+		if b.LogEnabled(Entry{Level: Debug}) {
+			t.Error("LogEnabled(Debug) = true after replay; want false")
+		}
+	})
+
+	t.Run("ReplayContextEnabled", func(t *testing.T) {
+		b := &startupBuffer{max: 10, timer: time.NewTimer(time.Hour)}
+		b.Log(WithMinLevel(ctx, Debug), Entry{Msg: "debug", Level: Debug})
+		buf := new(bytes.Buffer)
+		b.replay(&LevelFilter{Min: Info, Output: New(buf, "", 0, nil)})
+		if got, want := buf.String(), "debug\n"; got != want {
+			t.Errorf("output = %q; want %q", got, want)
+		}
+	})
+
+	t.Run("ReplayAfterExpire", func(t *testing.T) {
+		stderr := new(bytes.Buffer)
+		b := &startupBuffer{
+			max:      10,
+			timer:    time.NewTimer(time.Hour),
+			fallback: New(stderr, "", ShowLevel, nil),
+		}
+		b.Log(ctx, Entry{Msg: "early", Level: Info})
+		b.expire()
+		wantStderr := "WARN: log: logger never configured; writing entries from startup to stderr\n" +
+			"INFO: early\n"
+		if diff := cmp.Diff(wantStderr, stderr.String()); diff != "" {
+			t.Errorf("fallback output (-want +got):\n%s", diff)
+		}
+		buf := new(bytes.Buffer)
+		b.replay(New(buf, "", 0, nil))
+		b.Log(ctx, Entry{Msg: "hello"})
+		if got, want := buf.String(), "hello\n"; got != want {
+			t.Errorf("output = %q; want %q", got, want)
+		}
+	})
+}
+
+func TestBufferUntilConfigured(t *testing.T) {
+	ctx := context.Background()
+	orig := ReplaceDefault(Discard)
+	defer ReplaceDefault(orig)
+
+	// Simulate an unconfigured global logger.
+	defaultLogger.mu.Lock()
+	defaultLogger.out = atomic.Value{}
+	defaultLogger.mu.Unlock()
+
+	BufferUntilConfigured(10, time.Hour)
+	Infof(ctx, "early")
+	buf := new(bytes.Buffer)
+	if old := ReplaceDefault(New(buf, "", 0, nil)); old != fallback {
+		t.Errorf("ReplaceDefault(...) = %v; want fallback", old)
+	}
+	Infof(ctx, "late")
+	if got, want := buf.String(), "early\nlate\n"; got != want {
+		t.Errorf("output = %q; want %q", got, want)
+	}
+
+	// Calling again after the logger is configured has no effect.
+	BufferUntilConfigured(10, time.Hour)
+	Infof(ctx, "configured")
+	if !strings.HasSuffix(buf.String(), "configured\n") {
+		t.Errorf("output = %q; want entry logged after BufferUntilConfigured", buf.String())
+	}
+}
+
+func TestBufferUntilConfiguredFull(t *testing.T) {
+	ctx := context.Background()
+	orig := ReplaceDefault(Discard)
+	defer ReplaceDefault(orig)
+
+	// Simulate an unconfigured global logger.
+	defaultLogger.mu.Lock()
+	defaultLogger.out = atomic.Value{}
+	defaultLogger.mu.Unlock()
+
+	BufferUntilConfigured(2, time.Hour)
+	Debugf(ctx, "debug 1")
+	Debugf(ctx, "debug 2")
+	Infof(ctx, "important")
+	buf := new(bytes.Buffer)
+	ReplaceDefault(&LevelFilter{Min: Info, Output: New(buf, "", 0, nil)})
+	want := "important\n" +
+		"log: 1 entries were dropped before the logger was configured\n"
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("output (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build go1.14
+// +build go1.14
+
+package testlog
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"zombiezen.com/go/log"
+)
+
+// BufferOptions is the set of optional arguments to Buffer.
+type BufferOptions struct {
+	// If File is true, then the entries of a failed test are written to a file
+	// instead of the test output and the file's path is logged to the test.
+	// The file is placed in the test's artifact directory if the test binary
+	// was run with -artifacts (Go 1.26+) and in a new temporary directory
+	// otherwise.
+	File bool
+}
+
+// Buffer returns a Context that logs to tb like WithTB, except that entries
+// sent to this package's Logger are held in memory. When the test finishes,
+// the entries are written only if the test failed. Entries below the Logger's
+// minimum level are held as well, so the output of a failed test includes the
+// Debug entries that led to the failure. opts may be nil, in which
+// case it is treated the same as if new(BufferOptions) were passed.
+func Buffer(tb testing.TB, opts *BufferOptions) context.Context {
+	if opts == nil {
+		opts = new(BufferOptions)
+	}
+	buf := new(Recorder)
+	tb.Cleanup(func() {
+		if !tb.Failed() {
+			return
+		}
+		entries := buf.Entries()
+		if !opts.File {
+			for _, e := range entries {
+				logTB(tb, e)
+			}
+			return
+		}
+		path, err := writeBufferFile(tb, entries)
+		if err != nil {
+			tb.Errorf("write buffered log entries: %v", err)
+			return
+		}
+		tb.Logf("%d log entries written to %s", len(entries), path)
+	})
+	ctx := WithTB(context.Background(), tb)
+	return context.WithValue(ctx, bufferKey{}, buf)
+}
+
+// artifactDirer is implemented by *testing.T and *testing.B in Go 1.26+.
+type artifactDirer interface {
+	ArtifactDir() string
+}
+
+func writeBufferFile(tb testing.TB, entries []log.Entry) (string, error) {
+	var dir string
+	// Without -artifacts, ArtifactDir is removed when the test finishes.
+	if tba, ok := tb.(artifactDirer); ok && flagValue("test.artifacts") == "true" {
+		dir = tba.ArtifactDir()
+	} else {
+		var err error
+		dir, err = ioutil.TempDir("", "testlog")
+		if err != nil {
+			return "", err
+		}
+	}
+	const flags = log.ShowDate | log.ShowTime | log.Microseconds | log.ShortFile | log.ShowLevel
+	var buf []byte
+	for _, e := range entries {
+		buf = e.Append(buf, flags)
+		buf = append(buf, '\n')
+	}
+	name := strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(tb.Name())
+	path := filepath.Join(dir, name+".log")
+	if err := ioutil.WriteFile(path, buf, 0o666); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// flagValue returns the value of the named flag or the empty string
+// if the flag is not defined.
+func flagValue(name string) string {
+	f := flag.Lookup(name)
+	if f == nil {
+		return ""
+	}
+	return f.Value.String()
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build go1.14
+// +build go1.14
+
+package testlog
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"zombiezen.com/go/log"
+)
+
+func TestBuffer(t *testing.T) {
+	t.Run("Passed", func(t *testing.T) {
+		tb := new(fakeTB)
+		ctx := Buffer(tb, nil)
+		log.Logf(ctx, Logger{}, log.Info, "hello")
+		tb.runCleanups()
+		if len(tb.logs) != 0 {
+			t.Errorf("logs = %q; want none", tb.logs)
+		}
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		tb := new(fakeTB)
+		ctx := Buffer(tb, nil)
+		log.Logf(ctx, Logger{}, log.Info, "hello")
+		log.Logf(ctx, Logger{}, log.Warn, "world")
+		if len(tb.logs) != 0 {
+			t.Errorf("logs before cleanup = %q; want none", tb.logs)
+		}
+		tb.failed = true
+		tb.runCleanups()
+		want := []string{"hello", "WARN: world"}
+		if strings.Join(tb.logs, "\n") != strings.Join(want, "\n") {
+			t.Errorf("logs = %q; want %q", tb.logs, want)
+		}
+	})
+
+	t.Run("BelowLoggerMin", func(t *testing.T) {
+		tb := new(fakeTB)
+		ctx := Buffer(tb, nil)
+		min := log.Info
+		log.Logf(ctx, Logger{Min: &min}, log.Debug, "connecting")
+		tb.failed = true
+		tb.runCleanups()
+		want := []string{"connecting"}
+		if strings.Join(tb.logs, "\n") != strings.Join(want, "\n") {
+			t.Errorf("logs = %q; want %q", tb.logs, want)
+		}
+	})
+
+	t.Run("File", func(t *testing.T) {
+		tb := new(fakeTB)
+		ctx := Buffer(tb, &BufferOptions{File: true})
+		log.Logf(ctx, Logger{}, log.Error, "disk full")
+		tb.failed = true
+		tb.runCleanups()
+		if len(tb.logs) != 1 {
+			t.Fatalf("logs = %q; want 1 message with the file path", tb.logs)
+		}
+		path := tb.logs[0][strings.LastIndex(tb.logs[0], " ")+1:]
+		defer os.RemoveAll(filepath.Dir(path))
+		if got, want := filepath.Base(path), "TestFake_sub.log"; got != want {
+			t.Errorf("file name = %q; want %q", got, want)
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(data); !strings.Contains(got, " ERROR buffer_test.go:") || !strings.HasSuffix(got, ": disk full\n") {
+			t.Errorf("file content = %q; want entry with location and message", got)
+		}
+	})
+}
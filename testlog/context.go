@@ -0,0 +1,23 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build go1.14
+// +build go1.14
+
+package testlog
+
+import (
+	"context"
+	"testing"
+)
+
+// Context returns a Context that logs to tb like WithTB
+// and is cancelled when the test finishes.
+func Context(tb testing.TB) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	tb.Cleanup(cancel)
+	return WithTB(ctx, tb)
+}
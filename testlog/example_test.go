@@ -32,3 +32,24 @@ func ExampleWithTB() {
 	ctx := testlog.WithTB(context.Background(), t)
 	log.Infof(ctx, "Log to *testing.T")
 }
+
+func ExampleRecorder() {
+	// Inside a test. t is a *testing.T.
+	rec := new(testlog.Recorder)
+	log.Logf(context.Background(), rec, log.Warn, "cache miss for %q", "foo")
+	rec.Contains(t, log.Warn, "cache miss")
+	if n := rec.Count(log.Error); n > 0 {
+		t.Errorf("%d errors logged", n)
+	}
+}
+
+func ExampleStrict() {
+	// Inside a test. t is a *testing.T.
+	// You must have set the default logger using Main or log.SetDefault.
+	ctx := testlog.Strict(context.Background(), t, log.Warn)
+	log.Errorf(ctx, "This fails the test")
+
+	// Allow permits expected entries.
+	ctx = testlog.Allow(ctx, log.Warn, "retrying")
+	log.Warnf(ctx, "Connection refused; retrying")
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build go1.14
+// +build go1.14
+
+package testlog
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"zombiezen.com/go/log"
+)
+
+var update = flag.Bool("testlog.update", false, "rewrite testlog golden files")
+
+// updateGolden reports whether golden files should be rewritten.
+// A boolean -update flag defined by the test package is honored as well,
+// since it is a common convention for golden files.
+func updateGolden() bool {
+	if *update {
+		return true
+	}
+	f := flag.Lookup("update")
+	if f == nil {
+		return false
+	}
+	getter, ok := f.Value.(flag.Getter)
+	if !ok {
+		return false
+	}
+	b, _ := getter.Get().(bool)
+	return b
+}
+
+// GoldenOptions is the set of optional arguments to Golden.
+type GoldenOptions struct {
+	// If Lines is true, then line numbers are included in the output.
+	// Otherwise, they are omitted so that editing code does not change
+	// the output.
+	Lines bool
+}
+
+// Golden returns a Context that logs to tb like WithTB and records the
+// entries sent to this package's Logger. When the test finishes, the entries
+// are formatted one per line, normalized, and compared against the file
+// testdata/<name>.golden, relative to the test's working directory.
+// opts may be nil, in which case it is treated the same as if
+// new(GoldenOptions) were passed. Entries below log.Info are not compared,
+// since whether they are logged depends on the test's verbosity.
+//
+// Normalization replaces timestamps in messages with "<TIME>",
+// the working directory with "<WD>", the temporary directory with "<TMP>",
+// and entry file names with their base name.
+//
+// If the test binary is run with the -testlog.update flag, Golden rewrites
+// the file instead of comparing. If the test package defines its own boolean
+// -update flag, Golden also rewrites the file when it is set.
+func Golden(tb testing.TB, name string, opts *GoldenOptions) context.Context {
+	tb.Helper()
+	if opts == nil {
+		opts = new(GoldenOptions)
+	}
+	rec := new(Recorder)
+	path := filepath.Join("testdata", name+".golden")
+	tb.Cleanup(func() {
+		got := formatGolden(rec.Filter(log.Info), opts)
+		if updateGolden() {
+			if err := os.MkdirAll(filepath.Dir(path), 0o777); err != nil {
+				tb.Errorf("update golden file: %v", err)
+				return
+			}
+			if err := ioutil.WriteFile(path, got, 0o666); err != nil {
+				tb.Errorf("update golden file: %v", err)
+			}
+			return
+		}
+		want, err := ioutil.ReadFile(path)
+		if err != nil {
+			tb.Errorf("%v (run with -testlog.update to create)", err)
+			return
+		}
+		if string(got) != string(want) {
+			tb.Errorf("log output does not match %s (run with -testlog.update to rewrite)\ngot:\n%s\nwant:\n%s", path, got, want)
+		}
+	})
+	ctx := WithTB(context.Background(), tb)
+	return context.WithValue(ctx, recordKey{}, rec)
+}
+
+var timestampPattern = regexp.MustCompile(
+	`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:?\d{2})?` +
+		`|\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}(?:\.\d+)?`)
+
+func formatGolden(entries []log.Entry, opts *GoldenOptions) []byte {
+	var replacements []string
+	if wd, err := os.Getwd(); err == nil {
+		replacements = append(replacements, wd, "<WD>")
+	}
+	if tmp := strings.TrimSuffix(os.TempDir(), string(filepath.Separator)); tmp != "" {
+		replacements = append(replacements, tmp, "<TMP>")
+	}
+	replacer := strings.NewReplacer(replacements...)
+
+	var buf []byte
+	for _, e := range entries {
+		buf = append(buf, strings.ToUpper(e.Level.String())...)
+		if e.File != "" {
+			buf = append(buf, ' ')
+			buf = append(buf, filepath.Base(e.File)...)
+			if opts.Lines && e.Line >= 1 {
+				buf = append(buf, ':')
+				buf = strconv.AppendInt(buf, int64(e.Line), 10)
+			}
+			buf = append(buf, ':')
+		}
+		msg := timestampPattern.ReplaceAllLiteralString(e.Msg, "<TIME>")
+		msg = replacer.Replace(msg)
+		buf = append(buf, ' ')
+		buf = append(buf, msg...)
+		buf = append(buf, '\n')
+	}
+	return buf
+}
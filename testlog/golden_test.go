@@ -0,0 +1,122 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build go1.14
+// +build go1.14
+
+package testlog
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"zombiezen.com/go/log"
+)
+
+func TestGolden(t *testing.T) {
+	ctx := Golden(t, "TestGolden", nil)
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := Logger{}
+	log.Logf(ctx, l, log.Info, "started at %s", time.Now().Format(time.RFC3339Nano))
+	log.Logf(ctx, l, log.Warn, "reading %s", filepath.Join(wd, "config.json"))
+	log.Logf(ctx, l, log.Error, "temp file %s", filepath.Join(os.TempDir(), "x"))
+}
+
+func TestGoldenAboveLoggerMin(t *testing.T) {
+	// Output matches TestGolden even if the Logger does not write Info
+	// entries to the test log.
+	tb := new(fakeTB)
+	ctx := Golden(tb, "TestGolden", nil)
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	min := log.Error
+	l := Logger{Min: &min}
+	log.Logf(ctx, l, log.Info, "started at %s", time.Now().Format(time.RFC3339Nano))
+	log.Logf(ctx, l, log.Warn, "reading %s", filepath.Join(wd, "config.json"))
+	log.Logf(ctx, l, log.Error, "temp file %s", filepath.Join(os.TempDir(), "x"))
+	tb.runCleanups()
+	if len(tb.errors) != 0 {
+		t.Errorf("errors = %q; want none", tb.errors)
+	}
+}
+
+func TestGoldenMismatch(t *testing.T) {
+	tb := new(fakeTB)
+	ctx := Golden(tb, "TestGolden", nil)
+	log.Logf(ctx, Logger{}, log.Info, "something else")
+	tb.runCleanups()
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], "does not match") {
+		t.Errorf("errors = %q; want mismatch error", tb.errors)
+	}
+}
+
+func TestGoldenMissing(t *testing.T) {
+	tb := new(fakeTB)
+	Golden(tb, "does-not-exist", nil)
+	tb.runCleanups()
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], "-testlog.update") {
+		t.Errorf("errors = %q; want error suggesting -testlog.update", tb.errors)
+	}
+}
+
+// packageUpdate is an -update flag like one that a test package would define.
+// Defining it checks that this package does not register a conflicting flag.
+var packageUpdate = flag.Bool("update", false, "update golden files")
+
+func TestUpdateGolden(t *testing.T) {
+	if *update || *packageUpdate {
+		t.Skip("update flag set on command line")
+	}
+	if updateGolden() {
+		t.Error("updateGolden() = true with no flags set")
+	}
+	defer flag.Set("update", "false")
+	if err := flag.Set("update", "true"); err != nil {
+		t.Fatal(err)
+	}
+	if !updateGolden() {
+		t.Error("updateGolden() = false with test package's -update flag set")
+	}
+}
+
+func TestFormatGolden(t *testing.T) {
+	entries := []log.Entry{
+		{Level: log.Debug, Msg: "at 2009/01/23 01:23:23.123123", File: "/src/foo/bar.go", Line: 42},
+		{Level: log.Level(5), Msg: "no file"},
+	}
+	tests := []struct {
+		name string
+		opts *GoldenOptions
+		want string
+	}{
+		{
+			name: "Default",
+			opts: &GoldenOptions{},
+			want: "DEBUG bar.go: at <TIME>\nLEVEL(5) no file\n",
+		},
+		{
+			name: "Lines",
+			opts: &GoldenOptions{Lines: true},
+			want: "DEBUG bar.go:42: at <TIME>\nLEVEL(5) no file\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := string(formatGolden(entries, test.opts)); got != test.want {
+				t.Errorf("formatGolden(...) = %q; want %q", got, test.want)
+			}
+		})
+	}
+}
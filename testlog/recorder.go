@@ -0,0 +1,146 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package testlog
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"zombiezen.com/go/log"
+)
+
+// Recorder is a log.Logger that records every entry in memory
+// so that tests can make assertions about what was logged.
+// The zero value is an empty Recorder.
+// A Recorder is safe to use from multiple goroutines.
+type Recorder struct {
+	mu      sync.Mutex
+	entries []log.Entry
+	// dropped is the number of entries discarded by Reset.
+	dropped int
+	// changed is closed and cleared when an entry is recorded.
+	changed chan struct{}
+}
+
+// Log records e. A trailing newline in e.Msg is trimmed,
+// as a Logger that writes lines of text would do.
+func (r *Recorder) Log(ctx context.Context, e log.Entry) {
+	e.Msg = strings.TrimSuffix(e.Msg, "\n")
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, e)
+	if r.changed != nil {
+		close(r.changed)
+		r.changed = nil
+	}
+}
+
+// LogEnabled always returns true.
+func (r *Recorder) LogEnabled(log.Entry) bool {
+	return true
+}
+
+// Entries returns a copy of the recorded entries in the order they were logged.
+func (r *Recorder) Entries() []log.Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]log.Entry(nil), r.entries...)
+}
+
+// Filter returns the recorded entries at or above the given level
+// in the order they were logged.
+func (r *Recorder) Filter(min log.Level) []log.Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var filtered []log.Entry
+	for _, e := range r.entries {
+		if e.Level >= min {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+// Count returns the number of recorded entries at or above the given level.
+func (r *Recorder) Count(min log.Level) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, e := range r.entries {
+		if e.Level >= min {
+			n++
+		}
+	}
+	return n
+}
+
+// Contains reports whether an entry at or above the given level with
+// a message containing substr was recorded.
+// If not, it reports an error on tb that lists the recorded entries.
+func (r *Recorder) Contains(tb testing.TB, level log.Level, substr string) bool {
+	tb.Helper()
+	entries := r.Entries()
+	for _, e := range entries {
+		if e.Level >= level && strings.Contains(e.Msg, substr) {
+			return true
+		}
+	}
+	sb := new(strings.Builder)
+	for _, e := range entries {
+		sb.WriteString("\n\t")
+		sb.WriteString(e.String())
+	}
+	if sb.Len() == 0 {
+		sb.WriteString(" (none)")
+	}
+	tb.Errorf("no %v or higher entry containing %q was logged. Entries:%s", level, substr, sb)
+	return false
+}
+
+// Reset discards all recorded entries.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dropped += len(r.entries)
+	r.entries = nil
+}
+
+// WaitFor waits until an entry for which match returns true is recorded
+// and returns it. Entries recorded before the call are checked first.
+// WaitFor returns ctx.Err() if ctx is done before such an entry is recorded.
+func (r *Recorder) WaitFor(ctx context.Context, match func(log.Entry) bool) (log.Entry, error) {
+	// next counts entries ever recorded, so that it is not affected by Reset.
+	next := 0
+	for {
+		r.mu.Lock()
+		if next < r.dropped {
+			next = r.dropped
+		}
+		// Recorded entries are never modified, so the slice can be read
+		// without holding the lock.
+		unchecked := r.entries[next-r.dropped:]
+		next += len(unchecked)
+		if r.changed == nil {
+			r.changed = make(chan struct{})
+		}
+		changed := r.changed
+		r.mu.Unlock()
+
+		for _, e := range unchecked {
+			if match(e) {
+				return e, nil
+			}
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return log.Entry{}, ctx.Err()
+		}
+	}
+}
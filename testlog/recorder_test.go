@@ -0,0 +1,176 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package testlog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"zombiezen.com/go/log"
+)
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+	r := new(Recorder)
+	log.Logf(ctx, r, log.Debug, "one")
+	log.Logf(ctx, r, log.Warn, "two")
+	log.Logf(ctx, r, log.Error, "three")
+	log.Logf(ctx, r, log.Info, "four")
+
+	msgs := func(entries []log.Entry) []string {
+		var s []string
+		for _, e := range entries {
+			s = append(s, e.Msg)
+		}
+		return s
+	}
+	if diff := cmp.Diff([]string{"one", "two", "three", "four"}, msgs(r.Entries())); diff != "" {
+		t.Errorf("Entries() messages (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"two", "three"}, msgs(r.Filter(log.Warn))); diff != "" {
+		t.Errorf("Filter(log.Warn) messages (-want +got):\n%s", diff)
+	}
+	if got := r.Count(log.Info); got != 3 {
+		t.Errorf("Count(log.Info) = %d; want 3", got)
+	}
+	if !r.Contains(t, log.Warn, "tw") {
+		t.Error("Contains(t, log.Warn, \"tw\") = false")
+	}
+	if !r.Contains(t, log.Warn, "three") {
+		t.Error("Contains(t, log.Warn, \"three\") = false; want true for an Error entry")
+	}
+
+	r.Reset()
+	if got := r.Entries(); len(got) != 0 {
+		t.Errorf("after Reset, Entries() = %v; want []", got)
+	}
+}
+
+func TestRecorderContainsMissing(t *testing.T) {
+	r := new(Recorder)
+	log.Logf(context.Background(), r, log.Info, "hello")
+	tb := new(fakeTB)
+	if r.Contains(tb, log.Warn, "hello") {
+		t.Error("Contains(tb, log.Warn, \"hello\") = true; want false")
+	}
+	if len(tb.errors) != 1 {
+		t.Fatalf("Contains reported %d errors; want 1", len(tb.errors))
+	}
+	if !strings.Contains(tb.errors[0], "INFO: hello") {
+		t.Errorf("error %q does not list recorded entry", tb.errors[0])
+	}
+}
+
+func TestRecorderWaitFor(t *testing.T) {
+	ctx := context.Background()
+	r := new(Recorder)
+	log.Logf(ctx, r, log.Info, "before")
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		log.Logf(ctx, r, log.Info, "unrelated")
+		log.Logf(ctx, r, log.Warn, "done")
+	}()
+	waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	e, err := r.WaitFor(waitCtx, func(e log.Entry) bool {
+		return e.Level == log.Warn
+	})
+	if err != nil {
+		t.Fatal("WaitFor:", err)
+	}
+	if e.Msg != "done" {
+		t.Errorf("WaitFor(...) = %v; want entry with message \"done\"", e)
+	}
+
+	t.Run("Existing", func(t *testing.T) {
+		e, err := r.WaitFor(ctx, func(e log.Entry) bool {
+			return e.Msg == "before"
+		})
+		if err != nil || e.Msg != "before" {
+			t.Errorf("WaitFor(...) = %v, %v; want entry with message \"before\", <nil>", e, err)
+		}
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		canceledCtx, cancel := context.WithTimeout(ctx, 5*time.Millisecond)
+		defer cancel()
+		_, err := r.WaitFor(canceledCtx, func(log.Entry) bool { return false })
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("WaitFor(...) error = %v; want %v", err, context.DeadlineExceeded)
+		}
+	})
+}
+
+func TestRecorderTrailingNewline(t *testing.T) {
+	rec := new(Recorder)
+	rec.Log(context.Background(), log.Entry{Level: log.Info, Msg: "hello\n"})
+	if got := rec.Entries(); len(got) != 1 || got[0].Msg != "hello" {
+		t.Errorf("Entries() = %v; want one entry with message %q", got, "hello")
+	}
+}
+
+// fakeTB is a testing.TB that records errors and logs instead of
+// reporting them to the test.
+type fakeTB struct {
+	testing.TB
+	errors   []string
+	logs     []string
+	cleanups []func()
+	failed   bool
+	parallel bool
+}
+
+func (tb *fakeTB) Helper() {}
+
+func (tb *fakeTB) Errorf(format string, args ...interface{}) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+func (tb *fakeTB) Log(args ...interface{}) {
+	tb.logs = append(tb.logs, fmt.Sprint(args...))
+}
+
+// Output returns nil so that Logger uses Log.
+func (tb *fakeTB) Output() io.Writer { return nil }
+
+func (tb *fakeTB) Cleanup(f func()) {
+	tb.cleanups = append(tb.cleanups, f)
+}
+
+// runCleanups calls the functions registered with Cleanup in
+// last added, first called order.
+func (tb *fakeTB) runCleanups() {
+	for i := len(tb.cleanups) - 1; i >= 0; i-- {
+		tb.cleanups[i]()
+	}
+	tb.cleanups = nil
+}
+
+func (tb *fakeTB) Failed() bool { return tb.failed || len(tb.errors) > 0 }
+
+func (tb *fakeTB) Name() string { return "TestFake/sub" }
+
+func (tb *fakeTB) Logf(format string, args ...interface{}) {
+	tb.logs = append(tb.logs, fmt.Sprintf(format, args...))
+}
+
+func (tb *fakeTB) Fatalf(format string, args ...interface{}) {
+	tb.Errorf(format, args...)
+}
+
+// Setenv panics like (*testing.T).Setenv does in a parallel test.
+func (tb *fakeTB) Setenv(key, value string) {
+	if tb.parallel {
+		panic("testing: t.Setenv called after t.Parallel; cannot set environment variables in parallel tests")
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package testlog
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"zombiezen.com/go/log"
+)
+
+type strictKey struct{}
+
+type strictConfig struct {
+	tb  testing.TB
+	min log.Level
+}
+
+type allowKey struct{}
+
+// allowRule is a node in a list of rules added by Allow.
+type allowRule struct {
+	level  log.Level
+	substr string
+	next   *allowRule
+}
+
+// Strict returns a Context derived from parent that logs to tb like WithTB.
+// In addition, each entry at or above min that is sent to this package's
+// Logger with the Context reports an error on tb, unless the entry is
+// permitted by Allow. This turns errors that are logged and otherwise ignored
+// into test failures.
+func Strict(parent context.Context, tb testing.TB, min log.Level) context.Context {
+	ctx := WithTB(parent, tb)
+	return context.WithValue(ctx, strictKey{}, &strictConfig{tb: tb, min: min})
+}
+
+// Allow returns a Context derived from parent in which entries at exactly
+// the given level with a message containing substr do not fail a test that
+// uses Strict. Rules added by Allow accumulate: entries matching any rule in
+// the Context's ancestors are permitted.
+func Allow(parent context.Context, level log.Level, substr string) context.Context {
+	next, _ := parent.Value(allowKey{}).(*allowRule)
+	return context.WithValue(parent, allowKey{}, &allowRule{
+		level:  level,
+		substr: substr,
+		next:   next,
+	})
+}
+
+// checkStrict reports an error if e is not permitted by the Context.
+func checkStrict(ctx context.Context, e log.Entry) {
+	sc, _ := ctx.Value(strictKey{}).(*strictConfig)
+	if sc == nil || e.Level < sc.min {
+		return
+	}
+	for rule, _ := ctx.Value(allowKey{}).(*allowRule); rule != nil; rule = rule.next {
+		if e.Level == rule.level && strings.Contains(e.Msg, rule.substr) {
+			return
+		}
+	}
+	where := ""
+	if e.File != "" {
+		where = " at " + filepath.Base(e.File)
+		if e.Line >= 1 {
+			where += ":" + strconv.Itoa(e.Line)
+		}
+	}
+	sc.tb.Errorf("unexpected %v entry logged%s: %s", e.Level, where, e.Msg)
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package testlog
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"zombiezen.com/go/log"
+)
+
+func TestStrict(t *testing.T) {
+	tests := []struct {
+		name       string
+		allow      func(context.Context) context.Context
+		level      log.Level
+		msg        string
+		wantErrors int
+	}{
+		{
+			name:  "BelowMin",
+			level: log.Info,
+			msg:   "hello",
+		},
+		{
+			name:       "AtMin",
+			level:      log.Warn,
+			msg:        "disk almost full",
+			wantErrors: 1,
+		},
+		{
+			name:       "AboveMin",
+			level:      log.Error,
+			msg:        "disk full",
+			wantErrors: 1,
+		},
+		{
+			name: "Allowed",
+			allow: func(ctx context.Context) context.Context {
+				return Allow(ctx, log.Error, "disk full")
+			},
+			level: log.Error,
+			msg:   "write: disk full",
+		},
+		{
+			name: "AllowedByAncestor",
+			allow: func(ctx context.Context) context.Context {
+				ctx = Allow(ctx, log.Error, "disk full")
+				return Allow(ctx, log.Warn, "retrying")
+			},
+			level: log.Error,
+			msg:   "write: disk full",
+		},
+		{
+			name: "AllowWrongLevel",
+			allow: func(ctx context.Context) context.Context {
+				return Allow(ctx, log.Warn, "disk full")
+			},
+			level:      log.Error,
+			msg:        "write: disk full",
+			wantErrors: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tb := new(fakeTB)
+			ctx := Strict(context.Background(), tb, log.Warn)
+			if test.allow != nil {
+				ctx = test.allow(ctx)
+			}
+			log.Logf(ctx, Logger{}, test.level, "%s", test.msg)
+			if len(tb.logs) != 1 {
+				t.Errorf("logged %d times; want 1", len(tb.logs))
+			}
+			if len(tb.errors) != test.wantErrors {
+				t.Fatalf("errors = %q; want %d errors", tb.errors, test.wantErrors)
+			}
+			if test.wantErrors > 0 && !strings.Contains(tb.errors[0], "strict_test.go:") {
+				t.Errorf("error %q does not include the entry's location", tb.errors[0])
+			}
+		})
+	}
+}
+
+func TestStrictBelowLoggerMin(t *testing.T) {
+	min := log.Error
+	l := Logger{Min: &min}
+	tb := new(fakeTB)
+	ctx := Strict(context.Background(), tb, log.Warn)
+	log.Logf(ctx, l, log.Warn, "disk almost full")
+	if len(tb.errors) != 1 {
+		t.Errorf("errors = %q; want 1 error for Warn entry below Logger.Min", tb.errors)
+	}
+	if len(tb.logs) != 0 {
+		t.Errorf("logs = %q; want none below Logger.Min", tb.logs)
+	}
+}
+
+func TestStrictOnlyWithStrictContext(t *testing.T) {
+	tb := new(fakeTB)
+	ctx := WithTB(context.Background(), tb)
+	log.Logf(ctx, Logger{}, log.Error, "oops")
+	if len(tb.errors) != 0 {
+		t.Errorf("errors = %q; want none", tb.errors)
+	}
+}
@@ -10,9 +10,14 @@ package testlog
 
 import (
 	"context"
+	"flag"
 	"io"
+	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"sync"
+	"testing"
 
 	"zombiezen.com/go/log"
 )
@@ -22,20 +27,87 @@ type Logger struct {
 	// Fallback is the Logger used if the Context does not have a TB.
 	// If nil, then log.Discard is assumed.
 	Fallback log.Logger
+
+	// Min is the minimum level of entries written to a TB.
+	// If nil, then the level is read from the environment variable named
+	// by LevelEnv. If the variable is not set, then the level is log.Debug
+	// if testing.Verbose() reports true and log.Info otherwise.
+	Min *log.Level
 }
 
+// LevelEnv is the name of the environment variable that sets the default
+// minimum level for Logger. Its value may be a level name like "debug" or
+// "warn", or an integer.
+const LevelEnv = "TESTLOG_LEVEL"
+
 // Main sets the default logger to a testlog.Logger. fallback may be nil.
 // Main is intended to be called in TestMain.
 func Main(fallback log.Logger) {
-	log.SetDefault(Logger{fallback})
+	log.SetDefault(&defaultLogger{base: Logger{Fallback: fallback}})
+	installMu.Lock()
+	installed = true
+	installMu.Unlock()
+}
+
+var (
+	installMu sync.Mutex
+	installed bool // whether Main was called
+
+	overrideMu sync.RWMutex
+	override   log.Logger // set by Use
+)
+
+// swapOverride sets the logger used by the default logger installed by Main
+// and returns the previous one. A nil logger restores the testlog.Logger.
+func swapOverride(l log.Logger) log.Logger {
+	overrideMu.Lock()
+	defer overrideMu.Unlock()
+	prev := override
+	override = l
+	return prev
 }
 
-// Log writes to the TB in ctx or l.Fallback.
+// defaultLogger is the default logger installed by Main.
+type defaultLogger struct {
+	base Logger
+}
+
+func (d *defaultLogger) logger() log.Logger {
+	overrideMu.RLock()
+	defer overrideMu.RUnlock()
+	if override != nil {
+		return override
+	}
+	return d.base
+}
+
+func (d *defaultLogger) Log(ctx context.Context, e log.Entry) {
+	d.logger().Log(ctx, e)
+}
+
+func (d *defaultLogger) LogEnabled(e log.Entry) bool {
+	return d.logger().LogEnabled(e)
+}
+
+func (d *defaultLogger) LogEnabledContext(ctx context.Context, e log.Entry) bool {
+	return log.LogEnabledContext(ctx, d.logger(), e)
+}
+
+// Log writes to the TB in ctx or l.Fallback. Entries below the minimum level
+// are not written to the TB.
 //
 // If the TB has a method "Output() io.Writer",
 // then it will be used instead of TB.Log.
 // (See [*testing.T.Output] for details.)
+//
+// If ctx was derived from a Context returned by Strict,
+// then Log may also report an error for the entry.
+// If ctx was derived from a Context returned by Buffer,
+// then the entry is held until the test finishes, even if it is below
+// the minimum level.
 func (l Logger) Log(ctx context.Context, e log.Entry) {
+	defer checkStrict(ctx, e)
+	recordEntry(ctx, e)
 	tb, _ := ctx.Value(ctxKey{}).(TB)
 	if tb == nil {
 		if l.Fallback != nil {
@@ -43,7 +115,18 @@ func (l Logger) Log(ctx context.Context, e log.Entry) {
 		}
 		return
 	}
+	if buf, _ := ctx.Value(bufferKey{}).(*Recorder); buf != nil {
+		buf.Log(ctx, e)
+		return
+	}
+	if e.Level < l.min() {
+		return
+	}
+	logTB(tb, e)
+}
 
+// logTB writes e to tb.
+func logTB(tb TB, e log.Entry) {
 	if writeEntry(tb, e) {
 		return
 	}
@@ -58,13 +141,97 @@ func (l Logger) Log(ctx context.Context, e log.Entry) {
 	}
 }
 
-// LogEnabled always returns true.
+// LogEnabled reports whether e is at or above the minimum level
+// or whether l.Fallback is enabled for e.
 func (l Logger) LogEnabled(e log.Entry) bool {
-	return true
+	if e.Level >= l.min() {
+		return true
+	}
+	return l.Fallback != nil && l.Fallback.LogEnabled(e)
+}
+
+// LogEnabledContext is like LogEnabled, but also reports true for entries
+// below the minimum level that the Context would check or hold:
+// entries that Strict would report, entries recorded by Golden,
+// and entries held by Buffer.
+func (l Logger) LogEnabledContext(ctx context.Context, e log.Entry) bool {
+	if e.Level >= l.min() {
+		return true
+	}
+	if sc, _ := ctx.Value(strictKey{}).(*strictConfig); sc != nil && e.Level >= sc.min {
+		return true
+	}
+	if ctx.Value(recordKey{}) != nil || ctx.Value(bufferKey{}) != nil {
+		return true
+	}
+	return l.Fallback != nil && log.LogEnabledContext(ctx, l.Fallback, e)
+}
+
+func (l Logger) min() log.Level {
+	if l.Min != nil {
+		return *l.Min
+	}
+	return defaultMin()
+}
+
+var defaultMinState struct {
+	once  sync.Once
+	level log.Level
+}
+
+// defaultMin returns the minimum level for a Logger with a nil Min.
+func defaultMin() log.Level {
+	if level, ok := parseLevel(os.Getenv(LevelEnv)); ok {
+		return level
+	}
+	if !flag.Parsed() {
+		// testing.Verbose panics before the test flags are parsed,
+		// as in TestMain before calling m.Run.
+		return log.Info
+	}
+	defaultMinState.once.Do(func() {
+		defaultMinState.level = log.Info
+		if testing.Verbose() {
+			defaultMinState.level = log.Debug
+		}
+	})
+	return defaultMinState.level
+}
+
+func parseLevel(s string) (log.Level, bool) {
+	s = strings.TrimSpace(s)
+	switch strings.ToLower(s) {
+	case "":
+		return 0, false
+	case "debug":
+		return log.Debug, true
+	case "info":
+		return log.Info, true
+	case "warn", "warning":
+		return log.Warn, true
+	case "error":
+		return log.Error, true
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, false
+	}
+	return log.Level(i), true
 }
 
 type ctxKey struct{}
 
+type recordKey struct{}
+
+type bufferKey struct{}
+
+// recordEntry records e in the Recorder in ctx, if any.
+func recordEntry(ctx context.Context, e log.Entry) {
+	if rec, _ := ctx.Value(recordKey{}).(*Recorder); rec != nil {
+		rec.Log(ctx, e)
+	}
+}
+
 // WithTB returns a Context derived from parent that will use tb to log
 // when sending an entry to this package's Logger.
 func WithTB(parent context.Context, tb TB) context.Context {
@@ -0,0 +1,98 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package testlog
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"zombiezen.com/go/log"
+)
+
+func TestLoggerMin(t *testing.T) {
+	warn := log.Warn
+	tests := []struct {
+		name  string
+		min   *log.Level
+		env   string
+		level log.Level
+		want  bool
+	}{
+		{name: "ExplicitBelow", min: &warn, level: log.Info, want: false},
+		{name: "ExplicitAt", min: &warn, level: log.Warn, want: true},
+		{name: "ExplicitOverridesEnv", min: &warn, env: "debug", level: log.Debug, want: false},
+		{name: "EnvBelow", env: "error", level: log.Warn, want: false},
+		{name: "EnvAt", env: "ERROR", level: log.Error, want: true},
+		{name: "EnvInteger", env: "-10", level: log.Debug, want: true},
+		{name: "DefaultInfo", level: log.Info, want: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer setenv(LevelEnv, test.env)()
+			l := Logger{Min: test.min}
+			if got := l.LogEnabled(log.Entry{Level: test.level}); got != test.want {
+				t.Errorf("LogEnabled(Entry{Level: %v}) = %t; want %t", test.level, got, test.want)
+			}
+			tb := new(fakeTB)
+			l.Log(WithTB(context.Background(), tb), log.Entry{Level: test.level, Msg: "hi"})
+			if got := len(tb.logs) > 0; got != test.want {
+				t.Errorf("Log wrote to TB = %t; want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLoggerMinFallback(t *testing.T) {
+	defer setenv(LevelEnv, "error")()
+	rec := new(Recorder)
+	l := Logger{Fallback: rec}
+	if !l.LogEnabled(log.Entry{Level: log.Debug}) {
+		t.Error("LogEnabled(Entry{Level: Debug}) = false; want true because of Fallback")
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		s      string
+		want   log.Level
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"debug", log.Debug, true},
+		{"Info", log.Info, true},
+		{"warning", log.Warn, true},
+		{" error ", log.Error, true},
+		{"15", log.Level(15), true},
+		{" -10 ", log.Debug, true},
+		{"verbose", 0, false},
+	}
+	for _, test := range tests {
+		got, ok := parseLevel(test.s)
+		if got != test.want || ok != test.wantOK {
+			t.Errorf("parseLevel(%q) = %v, %t; want %v, %t", test.s, got, ok, test.want, test.wantOK)
+		}
+	}
+}
+
+// setenv sets an environment variable and returns a function that restores
+// its previous value. An empty value unsets the variable.
+func setenv(key, value string) (restore func()) {
+	old, hadOld := os.LookupEnv(key)
+	if value == "" {
+		os.Unsetenv(key)
+	} else {
+		os.Setenv(key, value)
+	}
+	return func() {
+		if hadOld {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
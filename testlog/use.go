@@ -0,0 +1,71 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build go1.17
+// +build go1.17
+
+package testlog
+
+import (
+	"testing"
+
+	"zombiezen.com/go/log"
+)
+
+// useEnv is set with tb.Setenv to detect parallel tests.
+const useEnv = "TESTLOG_USE"
+
+// Use sends entries for the default logger to l until the test finishes,
+// then restores the previous logger. Calls to Use in subtests nest.
+//
+// If Main has not been called, Use installs the logger that Main would with
+// log.ReplaceDefault and restores the previous default logger when the test
+// finishes.
+//
+// Since the default logger is shared by the whole process, Use must not be
+// called from a parallel test. Use reports a fatal error if the test or one of
+// its parents has called t.Parallel, and the test panics if it calls
+// t.Parallel after Use. (Use marks the test by setting an environment variable
+// with tb.Setenv.)
+func Use(tb testing.TB, l log.Logger) {
+	tb.Helper()
+	if l == nil {
+		panic("testlog.Use(tb, nil)")
+	}
+	if !markSerial(tb) {
+		tb.Fatalf("testlog.Use called from a parallel test")
+		return
+	}
+	installMu.Lock()
+	needMain := !installed
+	installed = true
+	installMu.Unlock()
+	if needMain {
+		old := log.ReplaceDefault(&defaultLogger{})
+		tb.Cleanup(func() {
+			log.ReplaceDefault(old)
+			installMu.Lock()
+			installed = false
+			installMu.Unlock()
+		})
+	}
+
+	prev := swapOverride(l)
+	tb.Cleanup(func() {
+		swapOverride(prev)
+	})
+}
+
+// markSerial calls tb.Setenv, which panics in parallel tests.
+func markSerial(tb testing.TB) (ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	tb.Setenv(useEnv, tb.Name())
+	return true
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build go1.17
+// +build go1.17
+
+package testlog
+
+import (
+	"context"
+	"testing"
+
+	"zombiezen.com/go/log"
+)
+
+func TestContext(t *testing.T) {
+	tb := new(fakeTB)
+	ctx := Context(tb)
+	if ctx.Value(ctxKey{}) != TB(tb) {
+		t.Error("Context does not carry the TB")
+	}
+	if err := ctx.Err(); err != nil {
+		t.Fatalf("ctx.Err() = %v before cleanup", err)
+	}
+	tb.runCleanups()
+	if err := ctx.Err(); err != context.Canceled {
+		t.Errorf("ctx.Err() = %v after cleanup; want %v", err, context.Canceled)
+	}
+}
+
+func TestUse(t *testing.T) {
+	ctx := context.Background()
+	outer := new(Recorder)
+	inner := new(Recorder)
+	t.Run("Outer", func(t *testing.T) {
+		Use(t, outer)
+		log.Infof(ctx, "outer 1")
+		t.Run("Inner", func(t *testing.T) {
+			Use(t, inner)
+			log.Infof(ctx, "inner")
+		})
+		log.Infof(ctx, "outer 2")
+	})
+	log.Infof(ctx, "after")
+
+	msgs := func(r *Recorder) []string {
+		var s []string
+		for _, e := range r.Entries() {
+			s = append(s, e.Msg)
+		}
+		return s
+	}
+	if got := msgs(outer); len(got) != 2 || got[0] != "outer 1" || got[1] != "outer 2" {
+		t.Errorf("outer logger messages = %q; want [\"outer 1\" \"outer 2\"]", got)
+	}
+	if got := msgs(inner); len(got) != 1 || got[0] != "inner" {
+		t.Errorf("inner logger messages = %q; want [\"inner\"]", got)
+	}
+}
+
+func TestUseParallel(t *testing.T) {
+	tb := &fakeTB{parallel: true}
+	rec := new(Recorder)
+	Use(tb, rec)
+	if len(tb.errors) != 1 {
+		t.Errorf("errors = %q; want 1 error", tb.errors)
+	}
+	log.Infof(context.Background(), "hello")
+	if n := len(rec.Entries()); n != 0 {
+		t.Errorf("logger received %d entries; want 0", n)
+	}
+}
+
+func TestUseRestoresDefault(t *testing.T) {
+	ctx := context.Background()
+	prev := new(Recorder)
+	old := log.ReplaceDefault(prev)
+	defer log.ReplaceDefault(old)
+
+	tb := new(fakeTB)
+	rec := new(Recorder)
+	Use(tb, rec)
+	log.Infof(ctx, "during")
+	tb.runCleanups()
+	log.Infof(ctx, "after")
+
+	if got := prev.Entries(); len(got) != 1 || got[0].Msg != "after" {
+		t.Errorf("previous default logger entries = %v; want one entry with message \"after\"", got)
+	}
+	if got := rec.Entries(); len(got) != 1 || got[0].Msg != "during" {
+		t.Errorf("Use logger entries = %v; want one entry with message \"during\"", got)
+	}
+}
@@ -16,7 +16,10 @@ import (
 )
 
 func setDefaultOutput(dst log.Logger, opts *Options) {
-	stdlog.SetFlags(stdlogFlags)
-	stdlog.SetPrefix("")
-	stdlog.SetOutput(newWriter(dst, opts))
+	// Without log.Default(), the writer cannot read the prefix when parsing,
+	// so it records the prefix that is set now.
+	w := newWriter(dst, opts)
+	w.prefix = stdlog.Prefix()
+	stdlog.SetFlags(w.flags)
+	stdlog.SetOutput(w)
 }
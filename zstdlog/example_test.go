@@ -52,3 +52,30 @@ func ExampleNew() {
 		log.Errorf(ctx, "%v", err)
 	}
 }
+
+// Libraries often mark the severity of a message in its text.
+// LevelRules converts these markers to levels.
+func ExampleCommonLevelRules() {
+	stdlogger := zstdlog.New(log.New(os.Stdout, "", log.ShowLevel, nil), &zstdlog.Options{
+		LevelRules: zstdlog.CommonLevelRules(),
+	})
+	stdlogger.Print("[WARN] disk almost full")
+	stdlogger.Print("error: disk full")
+	stdlogger.Print("Hello, World!")
+	// Output:
+	// WARN: disk almost full
+	// ERROR: disk full
+	// INFO: Hello, World!
+}
+
+// A Logger can replace a *log.Logger with few changes to the code that uses it,
+// while also permitting leveled messages.
+func ExampleLogger() {
+	logger := zstdlog.NewLogger(log.New(os.Stdout, "", log.ShowLevel, nil), nil)
+	logger.SetPrefix("app: ")
+	logger.Printf("Starting %s", "server")
+	logger.Warnf("Disk %d%% full", 90)
+	// Output:
+	// INFO: app: Starting server
+	// WARN: app: Disk 90% full
+}
@@ -0,0 +1,22 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build go1.21
+// +build go1.21
+
+package zstdlog
+
+import stdlog "log"
+
+// liveFormat returns the current flags and prefix of l.
+// Go 1.21+: Flags and Prefix do not acquire the lock held while
+// the Logger's output is being written, so they are safe to call from Write.
+func liveFormat(l *stdlog.Logger) (flags int, prefix string, ok bool) {
+	if l == nil {
+		return 0, "", false
+	}
+	return l.Flags(), l.Prefix(), true
+}
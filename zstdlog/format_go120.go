@@ -0,0 +1,18 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build !go1.21
+// +build !go1.21
+
+package zstdlog
+
+import stdlog "log"
+
+// liveFormat always returns false. In older versions of Go, the Flags and
+// Prefix methods may deadlock if called from the Logger's output.
+func liveFormat(l *stdlog.Logger) (flags int, prefix string, ok bool) {
+	return 0, "", false
+}
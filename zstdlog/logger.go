@@ -0,0 +1,232 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package zstdlog
+
+import (
+	"fmt"
+	"io"
+	stdlog "log"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"zombiezen.com/go/log"
+)
+
+// Logger has the methods of the standard library's *log.Logger, but sends
+// entries to a zombiezen.com/go/log.Logger. It also has methods to log at a
+// specific level. This permits code that stores a *log.Logger to switch to
+// *zstdlog.Logger without changing its calls.
+//
+// Print, Printf, Println, and Output log at the level given in the options
+// or by a matching level rule. Fatal and Panic methods log at log.Error.
+//
+// A Logger can be used simultaneously from multiple goroutines.
+type Logger struct {
+	w *writer
+
+	mu     sync.Mutex
+	prefix string
+	flags  int
+	// std is non-nil if SetOutput was called with a non-nil writer,
+	// in which case it formats all entries.
+	std *stdlog.Logger
+}
+
+// exit is os.Exit, replaced during tests.
+var exit = os.Exit
+
+// NewLogger returns a new Logger that sends entries to dst. opts may be nil,
+// in which case it is treated the same as if new(Options) were passed.
+func NewLogger(dst log.Logger, opts *Options) *Logger {
+	return &Logger{
+		w:     newWriter(dst, opts),
+		flags: stdlog.LstdFlags,
+	}
+}
+
+// Output logs s. Calldepth is the count of the number of frames to skip
+// when computing the file name and line number. A value of 1 will use
+// the caller of Output. Output always returns nil unless SetOutput was called,
+// in which case it returns the error from writing s.
+func (l *Logger) Output(calldepth int, s string) error {
+	level, msg := l.w.classify(s)
+	return l.output(calldepth+1, level, msg)
+}
+
+// Print logs a message. Arguments are handled in the manner of fmt.Print.
+func (l *Logger) Print(v ...interface{}) {
+	l.Output(2, fmt.Sprint(v...))
+}
+
+// Printf logs a message. Arguments are handled in the manner of fmt.Printf.
+func (l *Logger) Printf(format string, v ...interface{}) {
+	l.Output(2, fmt.Sprintf(format, v...))
+}
+
+// Println logs a message. Arguments are handled in the manner of fmt.Println.
+func (l *Logger) Println(v ...interface{}) {
+	l.Output(2, fmt.Sprintln(v...))
+}
+
+// Debugf logs a debug message. Arguments are handled in the manner of fmt.Printf.
+func (l *Logger) Debugf(format string, v ...interface{}) {
+	l.logf(log.Debug, format, v)
+}
+
+// Infof logs an info message. Arguments are handled in the manner of fmt.Printf.
+func (l *Logger) Infof(format string, v ...interface{}) {
+	l.logf(log.Info, format, v)
+}
+
+// Warnf logs a warning message. Arguments are handled in the manner of fmt.Printf.
+func (l *Logger) Warnf(format string, v ...interface{}) {
+	l.logf(log.Warn, format, v)
+}
+
+// Errorf logs an error message. Arguments are handled in the manner of fmt.Printf.
+func (l *Logger) Errorf(format string, v ...interface{}) {
+	l.logf(log.Error, format, v)
+}
+
+// Fatal is equivalent to Print at log.Error followed by a call to os.Exit(1).
+func (l *Logger) Fatal(v ...interface{}) {
+	l.output(2, log.Error, fmt.Sprint(v...))
+	exit(1)
+}
+
+// Fatalf is equivalent to Printf at log.Error followed by a call to os.Exit(1).
+func (l *Logger) Fatalf(format string, v ...interface{}) {
+	l.output(2, log.Error, fmt.Sprintf(format, v...))
+	exit(1)
+}
+
+// Fatalln is equivalent to Println at log.Error followed by a call to os.Exit(1).
+func (l *Logger) Fatalln(v ...interface{}) {
+	l.output(2, log.Error, fmt.Sprintln(v...))
+	exit(1)
+}
+
+// Panic is equivalent to Print at log.Error followed by a call to panic.
+func (l *Logger) Panic(v ...interface{}) {
+	s := fmt.Sprint(v...)
+	l.output(2, log.Error, s)
+	panic(s)
+}
+
+// Panicf is equivalent to Printf at log.Error followed by a call to panic.
+func (l *Logger) Panicf(format string, v ...interface{}) {
+	s := fmt.Sprintf(format, v...)
+	l.output(2, log.Error, s)
+	panic(s)
+}
+
+// Panicln is equivalent to Println at log.Error followed by a call to panic.
+func (l *Logger) Panicln(v ...interface{}) {
+	s := fmt.Sprintln(v...)
+	l.output(2, log.Error, s)
+	panic(s)
+}
+
+// Prefix returns the prefix of the logger.
+func (l *Logger) Prefix() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.prefix
+}
+
+// SetPrefix sets the prefix of the logger.
+// The prefix is added to each message as described in Options.FormatPrefix.
+func (l *Logger) SetPrefix(prefix string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.prefix = prefix
+	if l.std != nil {
+		l.std.SetPrefix(prefix)
+	}
+}
+
+// Flags returns the standard library output flags of the logger.
+func (l *Logger) Flags() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.flags
+}
+
+// SetFlags sets the standard library output flags of the logger.
+// The flags are only used after calling SetOutput, since entries have
+// their own time, file, and line.
+func (l *Logger) SetFlags(flag int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.flags = flag
+	if l.std != nil {
+		l.std.SetFlags(flag)
+	}
+}
+
+// SetOutput formats subsequent messages as the standard library logger would
+// and writes them to w instead of the zombiezen.com/go/log.Logger.
+// Calling SetOutput with a nil writer resumes sending entries to the
+// zombiezen.com/go/log.Logger.
+func (l *Logger) SetOutput(w io.Writer) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if w == nil {
+		l.std = nil
+		return
+	}
+	l.std = stdlog.New(w, l.prefix, l.flags)
+}
+
+// Writer returns the output destination for the logger. Unless SetOutput was
+// called, this is a writer that sends lines written by a standard library
+// logger to the zombiezen.com/go/log.Logger.
+func (l *Logger) Writer() io.Writer {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.std != nil {
+		return l.std.Writer()
+	}
+	return l.w
+}
+
+func (l *Logger) logf(level log.Level, format string, v []interface{}) {
+	if l.redirected() || log.LogEnabledContext(l.w.ctx, l.w.dst, log.Entry{Level: level}) {
+		l.output(3, level, fmt.Sprintf(format, v...))
+	}
+}
+
+func (l *Logger) redirected() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.std != nil
+}
+
+// output sends msg to the logger's destination. Calldepth is relative to
+// the caller of output.
+func (l *Logger) output(calldepth int, level log.Level, msg string) error {
+	ent := log.Entry{Time: time.Now(), Level: level}
+	l.mu.Lock()
+	prefix, std := l.prefix, l.std
+	l.mu.Unlock()
+	if std != nil {
+		return std.Output(calldepth+1, msg)
+	}
+	if !log.LogEnabledContext(l.w.ctx, l.w.dst, ent) {
+		return nil
+	}
+	if _, file, line, ok := runtime.Caller(calldepth); ok {
+		ent.File = file
+		ent.Line = line
+	}
+	ent.Msg = l.w.addPrefix(prefix, strings.TrimSuffix(msg, "\n"))
+	l.w.dst.Log(l.w.ctx, ent)
+	return nil
+}
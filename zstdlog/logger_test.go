@@ -0,0 +1,190 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package zstdlog
+
+import (
+	"bytes"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"zombiezen.com/go/log"
+)
+
+func TestLogger(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      *Options
+		log       func(l *Logger)
+		wantLevel log.Level
+		wantMsg   string
+	}{
+		{
+			name:      "Print",
+			log:       func(l *Logger) { l.Print("Hello, ", "World!") },
+			wantLevel: log.Info,
+			wantMsg:   "Hello, World!",
+		},
+		{
+			name:      "Println",
+			log:       func(l *Logger) { l.Println("Hello,", "World!") },
+			wantLevel: log.Info,
+			wantMsg:   "Hello, World!",
+		},
+		{
+			name:      "PrintfOptionsLevel",
+			opts:      &Options{Level: log.Warn},
+			log:       func(l *Logger) { l.Printf("Hello, %s!", "World") },
+			wantLevel: log.Warn,
+			wantMsg:   "Hello, World!",
+		},
+		{
+			name:      "PrintLevelRules",
+			opts:      &Options{LevelRules: CommonLevelRules()},
+			log:       func(l *Logger) { l.Print("[ERROR] disk full") },
+			wantLevel: log.Error,
+			wantMsg:   "disk full",
+		},
+		{
+			name:      "Debugf",
+			log:       func(l *Logger) { l.Debugf("x=%d", 42) },
+			wantLevel: log.Debug,
+			wantMsg:   "x=42",
+		},
+		{
+			name:      "Infof",
+			opts:      &Options{Level: log.Error},
+			log:       func(l *Logger) { l.Infof("x=%d", 42) },
+			wantLevel: log.Info,
+			wantMsg:   "x=42",
+		},
+		{
+			name:      "Warnf",
+			log:       func(l *Logger) { l.Warnf("x=%d", 42) },
+			wantLevel: log.Warn,
+			wantMsg:   "x=42",
+		},
+		{
+			name:      "Errorf",
+			log:       func(l *Logger) { l.Errorf("x=%d", 42) },
+			wantLevel: log.Error,
+			wantMsg:   "x=42",
+		},
+		{
+			name: "Prefix",
+			log: func(l *Logger) {
+				l.SetPrefix("http: ")
+				l.Print("Hello, World!")
+			},
+			wantLevel: log.Info,
+			wantMsg:   "http: Hello, World!",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cl := new(captureLogger)
+			l := NewLogger(cl, test.opts)
+			test.log(l)
+			if !cl.called {
+				t.Fatal("Log not called")
+			}
+			if cl.e.Level != test.wantLevel {
+				t.Errorf("Level = %v; want %v", cl.e.Level, test.wantLevel)
+			}
+			if cl.e.Msg != test.wantMsg {
+				t.Errorf("Msg = %q; want %q", cl.e.Msg, test.wantMsg)
+			}
+			if got, want := filepath.Base(cl.e.File), "logger_test.go"; got != want {
+				t.Errorf("File = %q; want %q", got, want)
+			}
+			if cl.e.Time.IsZero() {
+				t.Error("Time is not set")
+			}
+		})
+	}
+}
+
+func TestLoggerOutput(t *testing.T) {
+	cl := new(captureLogger)
+	l := NewLogger(cl, nil)
+	_, _, line, _ := runtime.Caller(0)
+	l.Output(1, "Hello, World!")
+	if cl.e.Line != line+1 {
+		t.Errorf("Line = %d; want %d", cl.e.Line, line+1)
+	}
+}
+
+func TestLoggerDisabled(t *testing.T) {
+	l := NewLogger(log.Discard, nil)
+	called := false
+	l.Debugf("%v", stringerFunc(func() string {
+		called = true
+		return ""
+	}))
+	if called {
+		t.Error("Debugf formatted its arguments for a disabled logger")
+	}
+}
+
+func TestLoggerFatal(t *testing.T) {
+	defer func(orig func(int)) { exit = orig }(exit)
+	code := -1
+	exit = func(c int) { code = c }
+
+	cl := new(captureLogger)
+	l := NewLogger(cl, nil)
+	l.Fatalf("bad %s", "thing")
+	if code != 1 {
+		t.Errorf("exit code = %d; want 1", code)
+	}
+	if cl.e.Level != log.Error || cl.e.Msg != "bad thing" {
+		t.Errorf("entry = %v; want ERROR: bad thing", cl.e)
+	}
+}
+
+func TestLoggerPanic(t *testing.T) {
+	cl := new(captureLogger)
+	l := NewLogger(cl, nil)
+	defer func() {
+		if got := recover(); got != "bad thing" {
+			t.Errorf("recover() = %#v; want %q", got, "bad thing")
+		}
+		if cl.e.Level != log.Error || cl.e.Msg != "bad thing" {
+			t.Errorf("entry = %v; want ERROR: bad thing", cl.e)
+		}
+	}()
+	l.Panicf("bad %s", "thing")
+}
+
+func TestLoggerSetOutput(t *testing.T) {
+	cl := new(captureLogger)
+	l := NewLogger(cl, nil)
+	buf := new(bytes.Buffer)
+	l.SetOutput(buf)
+	l.SetFlags(0)
+	l.SetPrefix("app: ")
+	l.Warnf("Hello, %s!", "World")
+	if cl.called {
+		t.Error("Log called after SetOutput")
+	}
+	if got, want := buf.String(), "app: Hello, World!\n"; got != want {
+		t.Errorf("output = %q; want %q", got, want)
+	}
+	if l.Writer() != buf {
+		t.Error("Writer() did not return writer passed to SetOutput")
+	}
+
+	l.SetOutput(nil)
+	l.Print("back")
+	if !cl.called || cl.e.Msg != "app: back" {
+		t.Errorf("after SetOutput(nil), entry = %v; want message %q", cl.e, "app: back")
+	}
+}
+
+type stringerFunc func() string
+
+func (f stringerFunc) String() string { return f() }
@@ -0,0 +1,163 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package zstdlog
+
+import (
+	stdlog "log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// msgPrefixFlag is the value of stdlog.Lmsgprefix, which was added in Go 1.14.
+const msgPrefixFlag = 1 << 6
+
+// header is the information parsed from a line written by a standard
+// library logger.
+type header struct {
+	time   time.Time // zero if the line did not include a timestamp
+	file   string
+	line   int
+	prefix string
+	msg    string
+}
+
+// parseLine parses a line written by a standard library logger with the
+// given flags and prefix. It returns false if the line does not match.
+func parseLine(line string, flags int, prefix string) (header, bool) {
+	var h header
+	rest := line
+	if flags&msgPrefixFlag == 0 && prefix != "" {
+		if !strings.HasPrefix(rest, prefix) {
+			return header{}, false
+		}
+		h.prefix = prefix
+		rest = rest[len(prefix):]
+	}
+
+	loc := time.Local
+	if flags&stdlog.LUTC != 0 {
+		loc = time.UTC
+	}
+	var date time.Time
+	if flags&stdlog.Ldate != 0 {
+		const layout = "2006/01/02"
+		if len(rest) < len(layout)+1 || rest[len(layout)] != ' ' {
+			return header{}, false
+		}
+		var err error
+		date, err = time.ParseInLocation(layout, rest[:len(layout)], loc)
+		if err != nil {
+			return header{}, false
+		}
+		h.time = date
+		rest = rest[len(layout)+1:]
+	}
+	if flags&(stdlog.Ltime|stdlog.Lmicroseconds) != 0 {
+		layout := "15:04:05"
+		if flags&stdlog.Lmicroseconds != 0 {
+			layout = "15:04:05.000000"
+		}
+		if len(rest) < len(layout)+1 || rest[len(layout)] != ' ' {
+			return header{}, false
+		}
+		clock, err := time.ParseInLocation(layout, rest[:len(layout)], loc)
+		if err != nil {
+			return header{}, false
+		}
+		if date.IsZero() {
+			// No date in the line: assume today.
+			date = time.Now().In(loc)
+		}
+		year, month, day := date.Date()
+		h.time = time.Date(year, month, day, clock.Hour(), clock.Minute(), clock.Second(), clock.Nanosecond(), loc)
+		rest = rest[len(layout)+1:]
+	}
+	if !h.time.IsZero() {
+		h.time = h.time.Local()
+	}
+	if flags&(stdlog.Lshortfile|stdlog.Llongfile) != 0 {
+		file, line, after, ok := cutFileLine(rest)
+		if !ok {
+			return header{}, false
+		}
+		if file != "???" {
+			h.file = file
+			h.line = line
+		}
+		rest = after
+	}
+	if flags&msgPrefixFlag != 0 && prefix != "" {
+		if !strings.HasPrefix(rest, prefix) {
+			return header{}, false
+		}
+		h.prefix = prefix
+		rest = rest[len(prefix):]
+	}
+	h.msg = rest
+	return h, true
+}
+
+// cutFileLine splits a "file:line: " header off the start of s.
+// The file name ends at the first colon followed by digits and ": ".
+func cutFileLine(s string) (file string, line int, rest string, ok bool) {
+	for i := 0; i < len(s) && s[i] != '\n'; i++ {
+		if s[i] != ':' {
+			continue
+		}
+		j := i + 1
+		for j < len(s) && '0' <= s[j] && s[j] <= '9' {
+			j++
+		}
+		if j == i+1 || !strings.HasPrefix(s[j:], ": ") {
+			continue
+		}
+		line, err := strconv.Atoi(s[i+1 : j])
+		if err != nil {
+			return "", 0, "", false
+		}
+		return s[:i], line, s[j+len(": "):], true
+	}
+	return "", 0, "", false
+}
+
+var (
+	headerStartPattern = regexp.MustCompile(`\d{4}/\d{2}/\d{2} |\d{2}:\d{2}:\d{2}(?:\.\d{6})? |(?:\S*\.go|\?\?\?):\d+: `)
+	datePattern        = regexp.MustCompile(`^\d{4}/\d{2}/\d{2} `)
+	timePattern        = regexp.MustCompile(`^\d{2}:\d{2}:\d{2}(?:\.\d{6})? `)
+	filePattern        = regexp.MustCompile(`^(?:\S*\.go|\?\?\?):\d+: `)
+)
+
+// guessLine parses a line written by a standard library logger whose flags
+// and prefix are unknown. It assumes that any text before the first date,
+// time, or Go file name is the prefix. A prefix written after the header
+// because of Lmsgprefix is treated as part of the message.
+func guessLine(line string) (header, bool) {
+	loc := headerStartPattern.FindStringIndex(line)
+	if loc == nil {
+		return header{}, false
+	}
+	prefix := line[:loc[0]]
+	rest := line[loc[0]:]
+	flags := 0
+	if datePattern.MatchString(rest) {
+		flags |= stdlog.Ldate
+		rest = rest[len("2006/01/02 "):]
+	}
+	if m := timePattern.FindString(rest); m != "" {
+		flags |= stdlog.Ltime
+		if len(m) > len("15:04:05 ") {
+			flags |= stdlog.Lmicroseconds
+		}
+		rest = rest[len(m):]
+	}
+	if filePattern.MatchString(rest) {
+		flags |= stdlog.Llongfile
+	}
+	return parseLine(line, flags, prefix)
+}
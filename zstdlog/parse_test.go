@@ -0,0 +1,165 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+package zstdlog
+
+import (
+	stdlog "log"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		name   string
+		line   string
+		flags  int
+		prefix string
+		want   header
+		fail   bool
+	}{
+		{
+			name:  "Default",
+			line:  "2021/02/03 04:05:06.123456 /src/foo.go:42: Hello, World!",
+			flags: stdlogFlags,
+			want: header{
+				time: time.Date(2021, time.February, 3, 4, 5, 6, 123456000, time.UTC),
+				file: "/src/foo.go",
+				line: 42,
+				msg:  "Hello, World!",
+			},
+		},
+		{
+			name:  "UnknownCaller",
+			line:  "2021/02/03 04:05:06.123456 ???:0: Hello",
+			flags: stdlogFlags,
+			want: header{
+				time: time.Date(2021, time.February, 3, 4, 5, 6, 123456000, time.UTC),
+				msg:  "Hello",
+			},
+		},
+		{
+			name:  "ColonsInFileAndMessage",
+			line:  "C:/src/foo.go:42: see bar.go:7: here",
+			flags: stdlog.Llongfile,
+			want: header{
+				file: "C:/src/foo.go",
+				line: 42,
+				msg:  "see bar.go:7: here",
+			},
+		},
+		{
+			name:  "NoFlags",
+			line:  "Hello: World",
+			flags: 0,
+			want:  header{msg: "Hello: World"},
+		},
+		{
+			name:   "PrefixShortFile",
+			line:   "http: 2021/02/03 04:05:06 server.go:3000: TLS handshake error",
+			flags:  stdlog.LstdFlags | stdlog.LUTC | stdlog.Lshortfile,
+			prefix: "http: ",
+			want: header{
+				time:   time.Date(2021, time.February, 3, 4, 5, 6, 0, time.UTC),
+				file:   "server.go",
+				line:   3000,
+				prefix: "http: ",
+				msg:    "TLS handshake error",
+			},
+		},
+		{
+			name:   "MsgPrefix",
+			line:   "2021/02/03 04:05:06 [proxy] error",
+			flags:  stdlog.LstdFlags | stdlog.LUTC | msgPrefixFlag,
+			prefix: "[proxy] ",
+			want: header{
+				time:   time.Date(2021, time.February, 3, 4, 5, 6, 0, time.UTC),
+				prefix: "[proxy] ",
+				msg:    "error",
+			},
+		},
+		{
+			name:   "MissingPrefix",
+			line:   "2021/02/03 04:05:06 error",
+			flags:  stdlog.LstdFlags,
+			prefix: "foo: ",
+			fail:   true,
+		},
+		{
+			name:  "WrongFlags",
+			line:  "Hello, World!",
+			flags: stdlogFlags,
+			fail:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := parseLine(test.line, test.flags, test.prefix)
+			if ok == test.fail {
+				t.Fatalf("parseLine(%q, %#x, %q) ok = %t; want %t", test.line, test.flags, test.prefix, ok, !test.fail)
+			}
+			if diff := cmp.Diff(test.want, got, cmp.AllowUnexported(header{})); !test.fail && diff != "" {
+				t.Errorf("parseLine(%q, %#x, %q) (-want +got):\n%s", test.line, test.flags, test.prefix, diff)
+			}
+		})
+	}
+}
+
+func TestGuessLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want header
+		fail bool
+	}{
+		{
+			name: "StdFlags",
+			line: "2021/02/03 04:05:06 Hello",
+			want: header{
+				time: time.Date(2021, time.February, 3, 4, 5, 6, 0, time.Local),
+				msg:  "Hello",
+			},
+		},
+		{
+			name: "PrefixMicrosecondsFile",
+			line: "foo: 2021/02/03 04:05:06.000001 bar.go:7: Hello",
+			want: header{
+				time:   time.Date(2021, time.February, 3, 4, 5, 6, 1000, time.Local),
+				file:   "bar.go",
+				line:   7,
+				prefix: "foo: ",
+				msg:    "Hello",
+			},
+		},
+		{
+			name: "FileOnly",
+			line: "/src/bar.go:7: Hello",
+			want: header{
+				file: "/src/bar.go",
+				line: 7,
+				msg:  "Hello",
+			},
+		},
+		{
+			name: "NoHeader",
+			line: "just a message",
+			fail: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := guessLine(test.line)
+			if ok == test.fail {
+				t.Fatalf("guessLine(%q) ok = %t; want %t", test.line, ok, !test.fail)
+			}
+			if diff := cmp.Diff(test.want, got, cmp.AllowUnexported(header{})); !test.fail && diff != "" {
+				t.Errorf("guessLine(%q) (-want +got):\n%s", test.line, diff)
+			}
+		})
+	}
+}
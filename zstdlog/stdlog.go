@@ -0,0 +1,103 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build go1.21
+// +build go1.21
+
+package zstdlog
+
+import (
+	"context"
+	stdlog "log"
+	"log/slog"
+	"runtime"
+
+	"zombiezen.com/go/log"
+)
+
+// Go 1.21+: The writer returned by slog.NewLogLogger records the current time
+// and a program counter and passes them to a slog.Handler, so the standard
+// library logger does not need to format a timestamp. The program counter is
+// always taken at the same stack depth, ignoring the calldepth passed to
+// (*log.Logger).Output, so the standard library logger still formats the file
+// and line for helpers that call Output directly.
+
+// directFlags are the flags used when the standard library logger
+// writes to a handler.
+const directFlags = stdlog.Llongfile
+
+func newStdLogger(w *writer) *stdlog.Logger {
+	w.flags = directFlags
+	l := slog.NewLogLogger(&handler{std: w}, slog.LevelInfo)
+	l.SetFlags(w.flags)
+	w.src = l
+	return l
+}
+
+func setOutput(w *writer, src *stdlog.Logger) {
+	w.flags = directFlags
+	w.src = src
+	w.prefix = src.Prefix()
+	out := slog.NewLogLogger(&handler{std: w}, slog.LevelInfo).Writer()
+	src.SetFlags(w.flags)
+	src.SetOutput(out)
+}
+
+// handler is a slog.Handler that sends records from a standard library logger
+// to a zombiezen.com/go/log.Logger. Attributes are not supported,
+// since the standard library logger never adds them.
+type handler struct {
+	std *writer
+}
+
+// Enabled reports whether the destination logger accepts entries at
+// the writer's level. If the writer has level rules, the level is not known
+// until the message is classified, so Enabled always returns true.
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	if len(h.std.rules) > 0 {
+		return true
+	}
+	return log.LogEnabledContext(h.std.ctx, h.std.dst, log.Entry{Level: h.std.level})
+}
+
+// Handle converts the record to an entry. The record's program counter is used
+// for the caller unless the message was logged with a direct call to Output,
+// in which case the file and line in the header honor the calldepth.
+// The header's time is used if present, otherwise the record's time.
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	ent := h.std.parse(r.Message)
+	if ent.Time.IsZero() {
+		ent.Time = r.Time
+	}
+	if r.PC != 0 && !(ent.File != "" && calledOutput()) {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		ent.File = frame.File
+		ent.Line = frame.Line
+	}
+	h.std.dst.Log(h.std.ctx, ent)
+	return nil
+}
+
+// calledOutput reports whether the standard library logger method on
+// the stack is Output, which takes an explicit calldepth.
+func calledOutput() bool {
+	var pcs [8]uintptr
+	n := runtime.Callers(3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if frame.Function == "log.(*Logger).output" {
+			next, _ := frames.Next()
+			return next.Function == "log.(*Logger).Output" || next.Function == "log.Output"
+		}
+		if !more {
+			return false
+		}
+	}
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler { return h }
+func (h *handler) WithGroup(name string) slog.Handler       { return h }
@@ -0,0 +1,25 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build !go1.21
+// +build !go1.21
+
+package zstdlog
+
+import stdlog "log"
+
+func newStdLogger(w *writer) *stdlog.Logger {
+	l := stdlog.New(w, "", w.flags)
+	w.src = l
+	return l
+}
+
+func setOutput(w *writer, src *stdlog.Logger) {
+	w.src = src
+	w.prefix = src.Prefix()
+	src.SetFlags(w.flags)
+	src.SetOutput(w)
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 The Zombie Zen Log Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build go1.21
+// +build go1.21
+
+package zstdlog
+
+import (
+	stdlog "log"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"zombiezen.com/go/log"
+)
+
+func TestDirectDelivery(t *testing.T) {
+	tests := []struct {
+		name   string
+		logger func(*captureLogger) *stdlog.Logger
+	}{
+		{"New", func(l *captureLogger) *stdlog.Logger {
+			return New(l, nil)
+		}},
+		{"SetOutput", func(l *captureLogger) *stdlog.Logger {
+			src := stdlog.New(nil, "", stdlog.LstdFlags)
+			SetOutput(l, src, nil)
+			return src
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			l := new(captureLogger)
+			stdlogger := test.logger(l)
+			before := time.Now()
+			_, _, line, _ := runtime.Caller(0)
+			stdlogger.Print("Hello, World!")
+			line++
+			after := time.Now()
+
+			if !l.called {
+				t.Fatal("Logger.Print did not trigger call to Log")
+			}
+			if want := "Hello, World!"; l.e.Msg != want {
+				t.Errorf("Msg = %q; want %q", l.e.Msg, want)
+			}
+			if got, want := filepath.Base(l.e.File), "stdlog_test.go"; got != want {
+				t.Errorf("File = %q; want %q", got, want)
+			}
+			if l.e.Line != line {
+				t.Errorf("Line = %d; want %d", l.e.Line, line)
+			}
+			// The time is not rounded to the precision of a text header.
+			if l.e.Time.Before(before) || l.e.Time.After(after) {
+				t.Errorf("Time = %v; want between %v and %v", l.e.Time, before, after)
+			}
+		})
+	}
+}
+
+func TestDirectDeliveryCalldepth(t *testing.T) {
+	l := new(captureLogger)
+	stdlogger := New(l, nil)
+	_, _, line, _ := runtime.Caller(0)
+	logHelper(stdlogger, "Hello, World!")
+	line++
+
+	if !l.called {
+		t.Fatal("Logger.Output did not trigger call to Log")
+	}
+	if want := "Hello, World!"; l.e.Msg != want {
+		t.Errorf("Msg = %q; want %q", l.e.Msg, want)
+	}
+	if got, want := filepath.Base(l.e.File), "stdlog_test.go"; got != want {
+		t.Errorf("File = %q; want %q", got, want)
+	}
+	if l.e.Line != line {
+		t.Errorf("Line = %d; want %d (the helper's caller)", l.e.Line, line)
+	}
+}
+
+// logHelper logs msg as if from its caller.
+func logHelper(l *stdlog.Logger, msg string) {
+	l.Output(2, msg)
+}
+
+func TestDirectDeliveryDisabled(t *testing.T) {
+	l := &filterLogger{}
+	stdlogger := New(l, nil)
+	stdlogger.Print("Hello, World!")
+	if l.called {
+		t.Error("Log called when LogEnabled returned false")
+	}
+}
+
+type filterLogger struct {
+	captureLogger
+}
+
+func (*filterLogger) LogEnabled(log.Entry) bool {
+	return false
+}
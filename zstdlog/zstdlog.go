@@ -10,9 +10,8 @@ package zstdlog
 
 import (
 	"context"
-	"fmt"
 	stdlog "log"
-	"strconv"
+	"regexp"
 	"strings"
 	"time"
 
@@ -24,17 +23,106 @@ type Options struct {
 	// Context is used if non-nil when logging entries. Otherwise,
 	// context.Background() is used.
 	Context context.Context
-	// Level is used for all created entries. Defaults to log.Info (the zero value).
+	// Level is used for all created entries that do not match any of
+	// LevelRules. Defaults to log.Info (the zero value).
 	Level log.Level
+	// LevelRules is checked in order against each message. The level of the
+	// first matching rule is used for the entry.
+	LevelRules []LevelRule
+	// FormatPrefix is called to combine the standard library logger's prefix
+	// (like "http: ") with a message. It is not called for loggers without
+	// a prefix. Level rules are checked against the message before the prefix
+	// is added. If FormatPrefix is nil, the prefix is prepended as-is.
+	// BracketPrefix formats the prefix as a component name.
+	FormatPrefix func(prefix, msg string) string
+}
+
+// BracketPrefix formats a prefix like "http: " as a component name in
+// brackets, like "[http] ". It can be used as Options.FormatPrefix.
+func BracketPrefix(prefix, msg string) string {
+	name := strings.TrimSuffix(strings.TrimSpace(prefix), ":")
+	if name == "" {
+		return msg
+	}
+	return "[" + name + "] " + msg
+}
+
+// A LevelRule assigns a level to messages that contain a marker like
+// "[ERROR]" or "warning:". Exactly one of Prefix or Pattern should be set.
+type LevelRule struct {
+	// Prefix matches messages that start with the given string,
+	// ignoring case.
+	Prefix string
+	// Pattern matches messages that contain a match of the regular expression.
+	// Anchor the expression with ^ to only match at the start of the message.
+	Pattern *regexp.Regexp
+
+	// Level is the level given to matching messages.
+	Level log.Level
+	// If Strip is true, then the matched text is removed from the message,
+	// along with any whitespace following a match at the start of the message.
+	Strip bool
+}
+
+// CommonLevelRules returns rules that recognize common level markers at the
+// start of a message, like "[ERROR]", "WARN:", or "debug:", and strip them.
+func CommonLevelRules() []LevelRule {
+	return []LevelRule{
+		{Pattern: commonErrorPattern, Level: log.Error, Strip: true},
+		{Pattern: commonWarnPattern, Level: log.Warn, Strip: true},
+		{Pattern: commonInfoPattern, Level: log.Info, Strip: true},
+		{Pattern: commonDebugPattern, Level: log.Debug, Strip: true},
+	}
+}
+
+var (
+	commonErrorPattern = regexp.MustCompile(`^(?i)(?:\[(?:error|err|fatal)\]:?|(?:error|err|fatal):)`)
+	commonWarnPattern  = regexp.MustCompile(`^(?i)(?:\[(?:warn|warning)\]:?|(?:warn|warning):)`)
+	commonInfoPattern  = regexp.MustCompile(`^(?i)(?:\[info\]:?|info:)`)
+	commonDebugPattern = regexp.MustCompile(`^(?i)(?:\[(?:debug|trace)\]:?|(?:debug|trace):)`)
+)
+
+// match reports whether the rule matches msg and returns the message with
+// the marker stripped if requested.
+func (rule *LevelRule) match(msg string) (string, bool) {
+	var start, end int
+	switch {
+	case rule.Prefix != "":
+		if len(msg) < len(rule.Prefix) || !strings.EqualFold(msg[:len(rule.Prefix)], rule.Prefix) {
+			return msg, false
+		}
+		start, end = 0, len(rule.Prefix)
+	case rule.Pattern != nil:
+		loc := rule.Pattern.FindStringIndex(msg)
+		if loc == nil {
+			return msg, false
+		}
+		start, end = loc[0], loc[1]
+	default:
+		return msg, false
+	}
+	if !rule.Strip {
+		return msg, true
+	}
+	if start == 0 {
+		return strings.TrimLeft(msg[end:], " \t"), true
+	}
+	return msg[:start] + msg[end:], true
 }
 
 // New returns a new standard library logger that writes to the given
 // zombiezen.com/go/log logger.
+//
+// On Go 1.21 and later, the returned logger passes the time of the call
+// directly to dst at full precision. On earlier versions, the logger formats
+// the time as text that is parsed to create the entry. In both cases, the
+// caller's file and line are parsed from the text.
 func New(dst log.Logger, opts *Options) *stdlog.Logger {
-	w := newWriter(dst, opts)
-	return stdlog.New(w, "", stdlogFlags)
+	return newStdLogger(newWriter(dst, opts))
 }
 
+// stdlogFlags are the flags used when the standard library logger
+// formats its header as text.
 const stdlogFlags = stdlog.Ldate |
 	stdlog.Ltime |
 	stdlog.Lmicroseconds |
@@ -43,11 +131,10 @@ const stdlogFlags = stdlog.Ldate |
 
 // SetOutput configures the standard library logger src to write to the given
 // zombiezen.com/go/log.Logger dst. opts may be nil, in which case it is treated
-// the same as if new(Options) were passed.
+// the same as if new(Options) were passed. The prefix of src is preserved
+// and added to messages as described in Options.FormatPrefix.
 func SetOutput(dst log.Logger, src *stdlog.Logger, opts *Options) {
-	src.SetFlags(stdlogFlags)
-	src.SetPrefix("")
-	src.SetOutput(newWriter(dst, opts))
+	setOutput(newWriter(dst, opts), src)
 }
 
 // SetDefaultOutput configures the default standard library logger to write to
@@ -60,57 +147,89 @@ func SetDefaultOutput(dst log.Logger, opts *Options) {
 type writer struct {
 	ctx   context.Context
 	level log.Level
+	rules []LevelRule
 	dst   log.Logger
+
+	formatPrefix func(prefix, msg string) string
+
+	// src is the standard library logger that writes to the writer, if known.
+	// flags and prefix are the format that was configured on src, which
+	// may be changed later if src is shared.
+	src    *stdlog.Logger
+	flags  int
+	prefix string
 }
 
 func newWriter(dst log.Logger, opts *Options) *writer {
 	w := &writer{
-		ctx: context.Background(),
-		dst: dst,
+		ctx:   context.Background(),
+		dst:   dst,
+		flags: stdlogFlags,
 	}
 	if opts != nil {
 		if opts.Context != nil {
 			w.ctx = opts.Context
 		}
 		w.level = opts.Level
+		w.rules = opts.LevelRules
+		w.formatPrefix = opts.FormatPrefix
 	}
 	return w
 }
 
+// classify returns the level for msg and the message to log.
+func (w *writer) classify(msg string) (log.Level, string) {
+	for i := range w.rules {
+		if stripped, ok := w.rules[i].match(msg); ok {
+			return w.rules[i].Level, stripped
+		}
+	}
+	return w.level, msg
+}
+
+// Write sends a line written by the standard library logger to w.dst.
+// Lines that cannot be parsed are sent as-is with the current time.
 func (w *writer) Write(p []byte) (int, error) {
-	const layout = "2006/01/02 15:04:05.999999 "
-	ps := string(p)
-	var ent log.Entry
-	var err error
-	ent.Time, err = time.Parse(layout, ps[:len(layout)])
-	if err != nil {
-		return 0, fmt.Errorf("log entry %q: invalid format: %v", p, err)
+	ent := w.parse(strings.TrimSuffix(string(p), "\n"))
+	if ent.Time.IsZero() {
+		ent.Time = time.Now()
 	}
-	ent.Time = ent.Time.Local()
+	w.dst.Log(w.ctx, ent)
+	return len(p), nil
+}
 
-	const msgSeparator = ": "
-	fileLineEnd := strings.Index(ps[len(layout):], msgSeparator)
-	if fileLineEnd == -1 {
-		return 0, fmt.Errorf("log entry %q: invalid format", p)
+// parse converts a line written by the standard library logger to an entry.
+// The entry's time is zero if the line does not have a timestamp.
+func (w *writer) parse(line string) log.Entry {
+	flags, prefix, ok := liveFormat(w.src)
+	if !ok {
+		flags, prefix = w.flags, w.prefix
 	}
-	fileLineEnd += len(layout)
-	fileLine := ps[len(layout):fileLineEnd]
-	if fileEnd := strings.LastIndex(fileLine, ":"); fileEnd == -1 {
-		ent.File = fileLine
-	} else {
-		ent.File = fileLine[:fileEnd]
-		ent.Line, err = strconv.Atoi(fileLine[fileEnd+len(":"):])
-		if err != nil {
-			return 0, fmt.Errorf("log entry %q: invalid format: %v", p, err)
-		}
+	h, ok := parseLine(line, flags, prefix)
+	if !ok {
+		h, ok = guessLine(line)
+	}
+	if !ok {
+		h = header{msg: line}
 	}
-	if ent.File == "???" {
-		ent.File = ""
-		ent.Line = 0
+	ent := log.Entry{
+		Time: h.time,
+		File: h.file,
+		Line: h.line,
 	}
+	ent.Level, ent.Msg = w.classify(h.msg)
+	ent.Msg = w.addPrefix(h.prefix, ent.Msg)
+	return ent
+}
 
-	ent.Level = w.level
-	ent.Msg = strings.TrimSuffix(ps[fileLineEnd+len(msgSeparator):], "\n")
-	w.dst.Log(w.ctx, ent)
-	return len(p), nil
+// addPrefix combines a standard library logger prefix with msg.
+func (w *writer) addPrefix(prefix, msg string) string {
+	switch {
+	case prefix == "":
+		return msg
+	case w.formatPrefix != nil:
+		return w.formatPrefix(prefix, msg)
+	default:
+		return prefix + msg
+	}
 }
@@ -8,9 +8,11 @@ package zstdlog
 
 import (
 	"context"
+	"io"
 	"io/ioutil"
 	stdlog "log"
 	"path/filepath"
+	"regexp"
 	"testing"
 	"time"
 
@@ -23,7 +25,7 @@ func TestNew(t *testing.T) {
 
 func TestSetOutput(t *testing.T) {
 	testAdapter(t, func(dst log.Logger, opts *Options) *stdlog.Logger {
-		src := stdlog.New(ioutil.Discard, "foo", stdlog.LstdFlags)
+		src := stdlog.New(ioutil.Discard, "", stdlog.LstdFlags)
 		SetOutput(dst, src, opts)
 		return src
 	})
@@ -107,6 +109,136 @@ func testAdapter(t *testing.T, newLogger func(log.Logger, *Options) *stdlog.Logg
 	})
 }
 
+func TestSetOutputPrefix(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *Options
+		want string
+	}{
+		{
+			name: "Default",
+			want: "http: TLS handshake error",
+		},
+		{
+			name: "BracketPrefix",
+			opts: &Options{FormatPrefix: BracketPrefix},
+			want: "[http] TLS handshake error",
+		},
+		{
+			name: "LevelRules",
+			opts: &Options{
+				LevelRules:   CommonLevelRules(),
+				FormatPrefix: BracketPrefix,
+			},
+			want: "[http] TLS handshake error",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			l := new(captureLogger)
+			src := stdlog.New(ioutil.Discard, "http: ", stdlog.LstdFlags)
+			SetOutput(l, src, test.opts)
+			if got, want := src.Prefix(), "http: "; got != want {
+				t.Errorf("src.Prefix() = %q; want %q", got, want)
+			}
+			msg := "TLS handshake error"
+			if test.opts != nil && test.opts.LevelRules != nil {
+				msg = "error: " + msg
+			}
+			src.Print(msg)
+			if !l.called {
+				t.Fatal("Logger.Print did not trigger call to Log")
+			}
+			if l.e.Msg != test.want {
+				t.Errorf("Msg = %q; want %q", l.e.Msg, test.want)
+			}
+			if got, want := filepath.Base(l.e.File), "zstdlog_test.go"; got != want {
+				t.Errorf("File = %q; want base name %q", l.e.File, want)
+			}
+		})
+	}
+}
+
+func TestSetDefaultOutputPrefix(t *testing.T) {
+	defer func(out io.Writer, prefix string, flags int) {
+		stdlog.SetOutput(out)
+		stdlog.SetPrefix(prefix)
+		stdlog.SetFlags(flags)
+	}(stdlog.Writer(), stdlog.Prefix(), stdlog.Flags())
+
+	l := new(captureLogger)
+	stdlog.SetPrefix("app: ")
+	SetDefaultOutput(l, &Options{FormatPrefix: BracketPrefix})
+	if got, want := stdlog.Prefix(), "app: "; got != want {
+		t.Errorf("stdlog.Prefix() = %q; want %q", got, want)
+	}
+	stdlog.Print("Hello, World!")
+	if !l.called {
+		t.Fatal("log.Print did not trigger call to Log")
+	}
+	if want := "[app] Hello, World!"; l.e.Msg != want {
+		t.Errorf("Msg = %q; want %q", l.e.Msg, want)
+	}
+}
+
+func TestBracketPrefix(t *testing.T) {
+	tests := []struct {
+		prefix string
+		want   string
+	}{
+		{"", "msg"},
+		{"http: ", "[http] msg"},
+		{"  ", "msg"},
+	}
+	for _, test := range tests {
+		if got := BracketPrefix(test.prefix, "msg"); got != test.want {
+			t.Errorf("BracketPrefix(%q, \"msg\") = %q; want %q", test.prefix, got, test.want)
+		}
+	}
+}
+
+func TestWriterTolerance(t *testing.T) {
+	t.Run("ChangedFlags", func(t *testing.T) {
+		l := new(captureLogger)
+		stdlogger := New(l, nil)
+		stdlogger.SetFlags(stdlog.LstdFlags | stdlog.Lshortfile)
+		stdlogger.SetPrefix("http: ")
+		stdlogger.Print("Hello, World!")
+		if !l.called {
+			t.Fatal("Logger.Print did not trigger call to Log")
+		}
+		if want := "http: Hello, World!"; l.e.Msg != want {
+			t.Errorf("Msg = %q; want %q", l.e.Msg, want)
+		}
+		if got, want := filepath.Base(l.e.File), "zstdlog_test.go"; got != want {
+			t.Errorf("File = %q; want base name %q", l.e.File, want)
+		}
+		if l.e.Time.IsZero() {
+			t.Error("Time is not set")
+		}
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		l := new(captureLogger)
+		w := newWriter(l, nil)
+		const msg = "not a standard log line"
+		before := time.Now()
+		n, err := w.Write([]byte(msg + "\n"))
+		if n != len(msg)+1 || err != nil {
+			t.Errorf("Write(...) = %d, %v; want %d, <nil>", n, err, len(msg)+1)
+		}
+		if !l.called {
+			t.Fatal("Write did not trigger call to Log")
+		}
+		if l.e.Msg != msg {
+			t.Errorf("Msg = %q; want %q", l.e.Msg, msg)
+		}
+		if l.e.Time.Before(before) {
+			t.Errorf("Time = %v; want current time", l.e.Time)
+		}
+	})
+}
+
 type captureLogger struct {
 	ctx    context.Context
 	e      log.Entry
@@ -142,3 +274,99 @@ func BenchmarkDiscard(b *testing.B) {
 		stdLogger.Printf("Hello World")
 	}
 }
+
+func TestLevelRules(t *testing.T) {
+	tests := []struct {
+		name      string
+		rules     []LevelRule
+		msg       string
+		wantLevel log.Level
+		wantMsg   string
+	}{
+		{
+			name:      "NoMatch",
+			rules:     CommonLevelRules(),
+			msg:       "Hello, World!",
+			wantLevel: log.Warn,
+			wantMsg:   "Hello, World!",
+		},
+		{
+			name:      "BracketError",
+			rules:     CommonLevelRules(),
+			msg:       "[ERROR] disk full",
+			wantLevel: log.Error,
+			wantMsg:   "disk full",
+		},
+		{
+			name:      "ColonWarn",
+			rules:     CommonLevelRules(),
+			msg:       "WARN: low memory",
+			wantLevel: log.Warn,
+			wantMsg:   "low memory",
+		},
+		{
+			name:      "LowercaseError",
+			rules:     CommonLevelRules(),
+			msg:       "error: bad thing",
+			wantLevel: log.Error,
+			wantMsg:   "bad thing",
+		},
+		{
+			name:      "Debug",
+			rules:     CommonLevelRules(),
+			msg:       "[debug]: details",
+			wantLevel: log.Debug,
+			wantMsg:   "details",
+		},
+		{
+			name:      "NotAtStart",
+			rules:     CommonLevelRules(),
+			msg:       "no error: here",
+			wantLevel: log.Warn,
+			wantMsg:   "no error: here",
+		},
+		{
+			name:      "PrefixNoStrip",
+			rules:     []LevelRule{{Prefix: "oops", Level: log.Error}},
+			msg:       "OOPS something",
+			wantLevel: log.Error,
+			wantMsg:   "OOPS something",
+		},
+		{
+			name:      "PatternStripMiddle",
+			rules:     []LevelRule{{Pattern: regexp.MustCompile(` \(fatal\)`), Level: log.Error, Strip: true}},
+			msg:       "db (fatal) gone",
+			wantLevel: log.Error,
+			wantMsg:   "db gone",
+		},
+		{
+			name: "FirstRuleWins",
+			rules: []LevelRule{
+				{Prefix: "x", Level: log.Debug},
+				{Prefix: "xy", Level: log.Error},
+			},
+			msg:       "xyz",
+			wantLevel: log.Debug,
+			wantMsg:   "xyz",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			l := new(captureLogger)
+			stdlogger := New(l, &Options{
+				Level:      log.Warn,
+				LevelRules: test.rules,
+			})
+			stdlogger.Print(test.msg)
+			if !l.called {
+				t.Fatal("Logger.Print did not trigger call to Log")
+			}
+			if l.e.Level != test.wantLevel {
+				t.Errorf("Level = %v; want %v", l.e.Level, test.wantLevel)
+			}
+			if l.e.Msg != test.wantMsg {
+				t.Errorf("Msg = %q; want %q", l.e.Msg, test.wantMsg)
+			}
+		})
+	}
+}